@@ -0,0 +1,563 @@
+package main
+
+import (
+	"bufio"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"os/signal"
+	"strings"
+
+	"github.com/bbarrington0099/gosyn/reference"
+)
+
+// Panes of the browser that keys act on.
+const (
+	paneTree = iota
+	paneContent
+)
+
+// Smallest terminal the browser draws in, and the narrowest it shows both
+// panes side by side in; narrower terminals show only the focused pane.
+const (
+	browseMinWidth  = 20
+	browseMinHeight = 3
+	browseTwoPanes  = 60
+)
+
+const browseHints = "j/k move  l/h open/close  tab pane  / filter  ^d/^u scroll  y copy  q quit"
+
+// treeRow is a line of the browser's tree: a section, or when subsection
+// is not -1, one of its subsections.
+type treeRow struct {
+	section    int
+	subsection int
+}
+
+// browser is the state of gosyn browse. It knows nothing about the
+// terminal: handleKey applies a key and view lays the state out for a
+// given size, so it can be driven by tests as well as by runBrowser.
+type browser struct {
+	sections  []reference.Section
+	expanded  map[int]bool
+	rows      []treeRow
+	cursor    int // index into rows
+	top       int // first row shown in the tree pane
+	scroll    int // first content line shown
+	focus     int
+	filter    string
+	filtering bool // keys are typed into the filter
+	status    string
+	page      int // height of the panes at the last view
+	copy      func(text string) (string, error)
+}
+
+func newBrowser(sections []reference.Section) *browser {
+	b := &browser{sections: sections, expanded: map[int]bool{}, page: 1, copy: copyToClipboard}
+	b.buildRows()
+	return b
+}
+
+// buildRows lists the visible tree rows. Without a filter these are the
+// sections and the subsections of expanded ones; with one, the sections
+// and subsections whose names contain the filter as a subsequence, with
+// every subsection of a matching section.
+func (b *browser) buildRows() {
+	var current treeRow
+	if b.cursor < len(b.rows) {
+		current = b.rows[b.cursor]
+	}
+	query := strings.ToLower(b.filter)
+	matches := func(name string) bool {
+		return reference.SubsequenceScore(query, strings.ToLower(name)) >= 0
+	}
+
+	b.rows = nil
+	for i, sec := range b.sections {
+		if b.filter == "" {
+			b.rows = append(b.rows, treeRow{i, -1})
+			if b.expanded[i] {
+				for j := range sec.Subsections {
+					b.rows = append(b.rows, treeRow{i, j})
+				}
+			}
+			continue
+		}
+		sectionMatches := matches(sec.Name) || matches(sec.Short)
+		var subs []treeRow
+		for j, sub := range sec.Subsections {
+			if sectionMatches || matches(sub.Name) {
+				subs = append(subs, treeRow{i, j})
+			}
+		}
+		if sectionMatches || len(subs) > 0 {
+			b.rows = append(b.rows, treeRow{i, -1})
+			b.rows = append(b.rows, subs...)
+		}
+	}
+
+	b.cursor = 0
+	for i, row := range b.rows {
+		if row == current {
+			b.cursor = i
+			break
+		}
+	}
+}
+
+func (b *browser) selected() (treeRow, bool) {
+	if b.cursor >= len(b.rows) {
+		return treeRow{}, false
+	}
+	return b.rows[b.cursor], true
+}
+
+func (b *browser) moveTo(cursor int) {
+	cursor = max(0, min(cursor, len(b.rows)-1))
+	if cursor != b.cursor {
+		b.cursor = cursor
+		b.scroll = 0
+	}
+}
+
+// handleKey applies a key, as named by readKey, and reports whether the
+// browser should close.
+func (b *browser) handleKey(key string) bool {
+	b.status = ""
+	if b.filtering {
+		switch key {
+		case "enter":
+			b.filtering = false
+		case "esc":
+			b.filtering, b.filter = false, ""
+			b.buildRows()
+		case "backspace":
+			if b.filter != "" {
+				runes := []rune(b.filter)
+				b.filter = string(runes[:len(runes)-1])
+				b.buildRows()
+			}
+		case "up", "ctrl-p":
+			b.moveTo(b.cursor - 1)
+		case "down", "ctrl-n":
+			b.moveTo(b.cursor + 1)
+		case "ctrl-c":
+			return true
+		default:
+			if len([]rune(key)) == 1 {
+				b.filter += key
+				b.scroll = 0
+				b.buildRows()
+			}
+		}
+		return false
+	}
+
+	switch key {
+	case "q", "ctrl-c":
+		return true
+	case "/":
+		b.filtering = true
+		b.focus = paneTree
+	case "esc":
+		if b.filter != "" {
+			b.filter = ""
+			b.buildRows()
+		}
+		b.focus = paneTree
+	case "tab":
+		b.focus = 1 - b.focus
+	case "ctrl-d", "pgdown":
+		b.scrollBy(max(1, b.page/2))
+	case "ctrl-u", "pgup":
+		b.scrollBy(-max(1, b.page/2))
+	case "J":
+		b.scrollBy(1)
+	case "K":
+		b.scrollBy(-1)
+	case "y":
+		b.copySelected()
+	case "?":
+		b.status = browseHints
+	default:
+		if b.focus == paneContent {
+			b.contentKey(key)
+		} else {
+			b.treeKey(key)
+		}
+	}
+	return false
+}
+
+func (b *browser) treeKey(key string) {
+	row, ok := b.selected()
+	switch key {
+	case "j", "down", "ctrl-n":
+		b.moveTo(b.cursor + 1)
+	case "k", "up", "ctrl-p":
+		b.moveTo(b.cursor - 1)
+	case "g", "home":
+		b.moveTo(0)
+	case "G", "end":
+		b.moveTo(len(b.rows) - 1)
+	case "l", "right", "enter":
+		switch {
+		case !ok:
+		case row.subsection >= 0:
+			b.focus = paneContent
+		case !b.expanded[row.section] && b.filter == "":
+			b.expanded[row.section] = true
+			b.buildRows()
+		default:
+			b.moveTo(b.cursor + 1)
+		}
+	case "h", "left":
+		switch {
+		case !ok:
+		case row.subsection >= 0:
+			for b.cursor > 0 && b.rows[b.cursor].subsection >= 0 {
+				b.moveTo(b.cursor - 1)
+			}
+		case b.expanded[row.section]:
+			b.expanded[row.section] = false
+			b.buildRows()
+		}
+	case " ":
+		if ok && row.subsection < 0 && b.filter == "" {
+			b.expanded[row.section] = !b.expanded[row.section]
+			b.buildRows()
+		}
+	}
+}
+
+func (b *browser) contentKey(key string) {
+	switch key {
+	case "j", "down", "enter":
+		b.scrollBy(1)
+	case "k", "up":
+		b.scrollBy(-1)
+	case "g", "home":
+		b.scroll = 0
+	case "G", "end":
+		b.scrollBy(len(b.contentLines()))
+	case "h", "left", "q":
+		b.focus = paneTree
+	}
+}
+
+func (b *browser) scrollBy(lines int) {
+	b.scroll = max(0, min(b.scroll+lines, len(b.contentLines())-b.page))
+}
+
+// content returns what the content pane shows for the selected row: a
+// whole section as by taxSection, or a single subsection.
+func (b *browser) content() string {
+	row, ok := b.selected()
+	if !ok {
+		return ""
+	}
+	sec := b.sections[row.section]
+	if row.subsection < 0 {
+		return taxSection(sec)
+	}
+	sub := sec.Subsections[row.subsection]
+	return fmt.Sprintf("%s%s%s/%s%s%s\n\n%s",
+		style(roleSection), sec.Name, Reset, // sectionName
+		style(roleSubsection), sub.Name, Reset, // subsectionName
+		reference.Render(sub, terminalRenderer))
+}
+
+// plainContent returns the snippet the selected row shows without styling,
+// for copying.
+func (b *browser) plainContent() string {
+	row, ok := b.selected()
+	if !ok {
+		return ""
+	}
+	sec := b.sections[row.section]
+	if row.subsection >= 0 {
+		return reference.Render(sec.Subsections[row.subsection], reference.Plain)
+	}
+	var parts []string
+	for _, sub := range sec.Subsections {
+		parts = append(parts, sub.Name+"\n\n"+reference.Render(sub, reference.Plain))
+	}
+	return strings.Join(parts, "\n\n")
+}
+
+func (b *browser) contentLines() []string {
+	return strings.Split(strings.TrimRight(b.content(), "\n"), "\n")
+}
+
+func (b *browser) copySelected() {
+	row, ok := b.selected()
+	if !ok {
+		return
+	}
+	name := b.sections[row.section].Name
+	if row.subsection >= 0 {
+		name += "/" + b.sections[row.section].Subsections[row.subsection].Name
+	}
+	how, err := b.copy(b.plainContent())
+	if err != nil {
+		b.status = fmt.Sprintf("%sERROR%s copy failed: %v", style(roleError), Reset, err)
+		return
+	}
+	b.status = fmt.Sprintf("Copied %s%s%s with %s", style(roleArg), name, Reset, how)
+}
+
+// view lays the browser out as exactly height lines for a terminal width
+// columns wide: a header, the tree and content panes, and a status line.
+// Below browseTwoPanes columns only the focused pane is shown, and a
+// terminal too small for anything gets a single notice.
+func (b *browser) view(width int, height int) []string {
+	if width < browseMinWidth || height < browseMinHeight {
+		lines := make([]string, max(1, height))
+		lines[0] = fitLine("terminal too small", width)
+		return lines
+	}
+	b.page = height - 2
+
+	if b.cursor < b.top {
+		b.top = b.cursor
+	}
+	if b.cursor >= b.top+b.page {
+		b.top = b.cursor - b.page + 1
+	}
+	content := b.contentLines()
+	b.scroll = max(0, min(b.scroll, len(content)-b.page))
+
+	header := fmt.Sprintf("%sgosyn browse%s", style(roleHeading), Reset)
+	if b.filter != "" || b.filtering {
+		header += fmt.Sprintf("  /%s%s%s", style(roleArg), b.filter, Reset)
+		if b.filtering {
+			header += "_"
+		}
+	}
+	status := b.status
+	if status == "" {
+		status = "? keys  " + browseHints
+	}
+
+	treeWidth, contentWidth := 0, width
+	if width >= browseTwoPanes {
+		longest := 0
+		for _, sec := range b.sections {
+			longest = max(longest, len(sec.Name))
+			for _, sub := range sec.Subsections {
+				longest = max(longest, len(sub.Name)+2)
+			}
+		}
+		treeWidth = max(20, min(longest+4, width/3))
+		contentWidth = width - treeWidth - 1
+	} else if b.focus == paneTree {
+		treeWidth, contentWidth = width, 0
+	}
+
+	lines := []string{fitLine(header, width)}
+	for i := 0; i < b.page; i++ {
+		var line string
+		if treeWidth > 0 {
+			line = fitLine(b.treeLine(b.top+i), treeWidth)
+		}
+		if treeWidth > 0 && contentWidth > 0 {
+			line += "│"
+		}
+		if contentWidth > 0 {
+			text := ""
+			if b.scroll+i < len(content) {
+				text = content[b.scroll+i]
+			}
+			line += fitLine(text, contentWidth)
+		}
+		lines = append(lines, line)
+	}
+	return append(lines, fitLine(status, width))
+}
+
+func (b *browser) treeLine(i int) string {
+	if i >= len(b.rows) {
+		return ""
+	}
+	row := b.rows[i]
+	marker := "  "
+	if i == b.cursor {
+		marker = "> "
+	}
+	sec := b.sections[row.section]
+	if row.subsection >= 0 {
+		return fmt.Sprintf("%s  %s%s%s", marker, style(roleSubsection), sec.Subsections[row.subsection].Name, Reset)
+	}
+	fold := "+"
+	if b.expanded[row.section] || b.filter != "" {
+		fold = "-"
+	}
+	return fmt.Sprintf("%s%s %s%s%s", marker, fold, style(roleSection), sec.Name, Reset)
+}
+
+// fitLine cuts s, which may contain ANSI sequences and tabs, to width
+// columns and pads it with spaces to exactly width, expanding tabs to four
+// columns and ending any style left open.
+func fitLine(s string, width int) string {
+	var out strings.Builder
+	column := 0
+	styled := false
+	for i := 0; i < len(s) && column < width; {
+		if loc := ansiPattern.FindStringIndex(s[i:]); loc != nil && loc[0] == 0 {
+			out.WriteString(s[i : i+loc[1]])
+			styled = true
+			i += loc[1]
+			continue
+		}
+		r := []rune(s[i:])[0]
+		i += len(string(r))
+		if r == '\t' {
+			spaces := min(4-column%4, width-column)
+			out.WriteString(strings.Repeat(" ", spaces))
+			column += spaces
+			continue
+		}
+		out.WriteRune(r)
+		column++
+	}
+	if styled {
+		out.WriteString(Reset)
+	}
+	out.WriteString(strings.Repeat(" ", width-column))
+	return out.String()
+}
+
+// readKey reads one key press from a terminal in raw mode, naming special
+// keys ("up", "enter", "ctrl-d", ...) and returning printable keys as
+// themselves. A lone ESC is told from an escape sequence by whether more
+// input is already waiting.
+func readKey(reader *bufio.Reader) (string, error) {
+	r, _, err := reader.ReadRune()
+	if err != nil {
+		return "", err
+	}
+	switch r {
+	case 27:
+		if reader.Buffered() == 0 {
+			return "esc", nil
+		}
+		keys := map[string]string{
+			"A": "up", "B": "down", "C": "right", "D": "left",
+			"H": "home", "1~": "home", "7~": "home",
+			"F": "end", "4~": "end", "8~": "end",
+			"5~": "pgup", "6~": "pgdown", "3~": "delete",
+		}
+		return keys[readEscape(reader)], nil
+	case '\r', '\n':
+		return "enter", nil
+	case 127, 8:
+		return "backspace", nil
+	case '\t':
+		return "tab", nil
+	case 3:
+		return "ctrl-c", nil
+	case 4:
+		return "ctrl-d", nil
+	case 14:
+		return "ctrl-n", nil
+	case 16:
+		return "ctrl-p", nil
+	case 21:
+		return "ctrl-u", nil
+	}
+	if r < ' ' {
+		return "", nil
+	}
+	return string(r), nil
+}
+
+// runBrowser runs gosyn browse on the terminal until q is pressed,
+// redrawing on every key and whenever the terminal is resized.
+func runBrowser(sections []reference.Section, in *os.File, out *os.File) error {
+	if !isTerminal(in) || !isTerminal(out) {
+		return withKind(ErrTerminal, fmt.Errorf("browse needs a terminal"))
+	}
+	state, err := makeRaw(in.Fd())
+	if err != nil {
+		return withKind(ErrTerminal, fmt.Errorf("cannot use the terminal: %w", err))
+	}
+	defer restoreTerminal(in.Fd(), state)
+	fmt.Fprint(out, "\033[?1049h\033[?25l")
+	defer fmt.Fprint(out, "\033[?25h\033[?1049l")
+
+	keys := make(chan string)
+	readErrors := make(chan error, 1)
+	go func() {
+		reader := bufio.NewReader(in)
+		for {
+			key, err := readKey(reader)
+			if err != nil {
+				readErrors <- err
+				return
+			}
+			keys <- key
+		}
+	}()
+	resized := make(chan os.Signal, 1)
+	notifyResize(resized)
+	defer signal.Stop(resized)
+
+	b := newBrowser(sections)
+	b.copy = func(text string) (string, error) {
+		how, err := copyToClipboard(text)
+		if err != nil {
+			fmt.Fprintf(out, "\033]52;c;%s\a", base64.StdEncoding.EncodeToString([]byte(text)))
+			return "the terminal (OSC 52)", nil
+		}
+		return how, nil
+	}
+	for {
+		width, height, err := terminalSize(out.Fd())
+		if err != nil {
+			width, height = 80, 24
+		}
+		fmt.Fprint(out, "\033[H"+strings.Join(b.view(width, height), "\r\n"))
+		select {
+		case key := <-keys:
+			if b.handleKey(key) {
+				return nil
+			}
+		case <-resized:
+			fmt.Fprint(out, "\033[2J")
+		case err := <-readErrors:
+			if err == io.EOF {
+				return nil
+			}
+			return withKind(ErrTerminal, fmt.Errorf("cannot use the terminal: %w", err))
+		}
+	}
+}
+
+// clipboardCommands are tried in order by copyToClipboard.
+var clipboardCommands = [][]string{
+	{"pbcopy"},
+	{"wl-copy"},
+	{"xclip", "-selection", "clipboard"},
+	{"xsel", "--clipboard", "--input"},
+	{"clip.exe"},
+}
+
+// copyToClipboard copies text with the first clipboard command installed,
+// returning its name.
+func copyToClipboard(text string) (string, error) {
+	for _, command := range clipboardCommands {
+		path, err := exec.LookPath(command[0])
+		if err != nil {
+			continue
+		}
+		cmd := exec.Command(path, command[1:]...)
+		cmd.Stdin = strings.NewReader(text)
+		if err := cmd.Run(); err != nil {
+			return "", err
+		}
+		return command[0], nil
+	}
+	return "", fmt.Errorf("no clipboard command found")
+}
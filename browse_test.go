@@ -0,0 +1,183 @@
+package main
+
+import (
+	"bufio"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/bbarrington0099/gosyn/reference"
+)
+
+func testBrowser() *browser {
+	long := strings.Repeat("line\n", 40)
+	return newBrowser([]reference.Section{
+		{Name: "Variables", Short: "var", Subsections: []reference.Subsection{{Name: "Declaration", Content: "var x int"}, {Name: "Types", Content: "int, string"}}},
+		{Name: "DataStructures", Short: "ds", Subsections: []reference.Subsection{{Name: "Structs", Content: long}}},
+	})
+}
+
+// Browser Keys
+func TestBrowserKeys(t *testing.T) {
+	tests := []struct {
+		name       string
+		keys       []string
+		wantRow    treeRow
+		wantFocus  int
+		wantFilter string
+	}{
+		{"starts on first section", nil, treeRow{0, -1}, paneTree, ""},
+		{"move down", []string{"j"}, treeRow{1, -1}, paneTree, ""},
+		{"expand and enter subsection", []string{"l", "l", "j"}, treeRow{0, 1}, paneTree, ""},
+		{"open subsection focuses content", []string{"l", "j", "l"}, treeRow{0, 0}, paneContent, ""},
+		{"h returns to section", []string{"l", "j", "j", "h"}, treeRow{0, -1}, paneTree, ""},
+		{"collapse hides subsections", []string{"l", "h", "j"}, treeRow{1, -1}, paneTree, ""},
+		{"filter keeps matches", []string{"/", "t", "y", "p", "enter"}, treeRow{0, -1}, paneTree, "typ"},
+		{"filter then move", []string{"/", "s", "t", "r", "u", "enter", "j"}, treeRow{1, 0}, paneTree, "stru"},
+		{"escape clears filter", []string{"/", "x", "esc"}, treeRow{0, -1}, paneTree, ""},
+		{"G goes to last row", []string{"G"}, treeRow{1, -1}, paneTree, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := testBrowser()
+			for _, key := range tt.keys {
+				if b.handleKey(key) {
+					t.Fatalf("handleKey(%q) closed the browser", key)
+				}
+			}
+			row, _ := b.selected()
+			if row != tt.wantRow || b.focus != tt.wantFocus || b.filter != tt.wantFilter {
+				t.Errorf("after %q at %+v focus %d filter %q, want %+v focus %d filter %q",
+					tt.keys, row, b.focus, b.filter, tt.wantRow, tt.wantFocus, tt.wantFilter)
+			}
+		})
+	}
+
+	if !testBrowser().handleKey("q") {
+		t.Error("handleKey(\"q\") should close the browser")
+	}
+}
+
+// Browser Filter
+func TestBrowserFilter(t *testing.T) {
+	b := testBrowser()
+	for _, key := range []string{"/", "t", "y", "p"} {
+		b.handleKey(key)
+	}
+	want := []treeRow{{0, -1}, {0, 1}}
+	if len(b.rows) != len(want) || b.rows[0] != want[0] || b.rows[1] != want[1] {
+		t.Errorf("rows filtered by \"typ\" = %+v, want %+v", b.rows, want)
+	}
+}
+
+// Browser Scroll
+func TestBrowserScroll(t *testing.T) {
+	b := testBrowser()
+	for _, key := range []string{"j", "l", "j"} {
+		b.handleKey(key)
+	}
+	b.view(80, 12)
+	b.handleKey("ctrl-d")
+	if b.scroll != 5 {
+		t.Errorf("scroll after ctrl-d = %d, want half of a 10 line page", b.scroll)
+	}
+	b.handleKey("tab")
+	b.handleKey("G")
+	if lines := len(b.contentLines()); b.scroll != lines-10 {
+		t.Errorf("scroll after G = %d, want %d", b.scroll, lines-10)
+	}
+	b.handleKey("k")
+	b.handleKey("k")
+	if lines := len(b.contentLines()); b.scroll != lines-12 {
+		t.Errorf("scroll after k = %d, want %d", b.scroll, lines-12)
+	}
+
+	// A taller terminal pulls the scroll back so no space is wasted
+	b.view(80, 60)
+	if b.scroll != 0 {
+		t.Errorf("scroll after growing = %d, want 0", b.scroll)
+	}
+}
+
+// Browser View
+func TestBrowserView(t *testing.T) {
+	tests := []struct {
+		name      string
+		width     int
+		height    int
+		wantPanes bool
+	}{
+		{"two panes", 100, 20, true},
+		{"narrow shows one pane", 40, 10, false},
+		{"too small", 10, 2, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := testBrowser()
+			lines := b.view(tt.width, tt.height)
+			if len(lines) != tt.height {
+				t.Fatalf("view() returned %d lines, want %d", len(lines), tt.height)
+			}
+			for _, line := range lines {
+				if got := utf8.RuneCountInString(stripANSI(line)); got != tt.width && tt.width >= browseMinWidth {
+					t.Errorf("view() line %q is %d columns, want %d", stripANSI(line), got, tt.width)
+				}
+			}
+			if got := strings.Contains(strings.Join(lines, "\n"), "│"); got != tt.wantPanes {
+				t.Errorf("view() shows both panes = %v, want %v", got, tt.wantPanes)
+			}
+		})
+	}
+}
+
+// Browser Copy
+func TestBrowserCopy(t *testing.T) {
+	b := testBrowser()
+	var copied string
+	b.copy = func(text string) (string, error) {
+		copied = text
+		return "test", nil
+	}
+	b.handleKey("l")
+	b.handleKey("j")
+	b.handleKey("y")
+	if copied != "var x int" {
+		t.Errorf("copied %q, want the plain snippet", copied)
+	}
+	if !strings.Contains(stripANSI(b.status), "Copied Variables/Declaration") {
+		t.Errorf("status = %q, want a copy confirmation", b.status)
+	}
+}
+
+// Fit Line
+func TestFitLine(t *testing.T) {
+	tests := []struct {
+		name string
+		s    string
+		want string
+	}{
+		{"pads", "ab", "ab    "},
+		{"cuts", "abcdefgh", "abcdef"},
+		{"expands tabs", "\tx", "    x "},
+		{"keeps styles and closes them", style(roleLiteral) + "abcdefgh", style(roleLiteral) + "abcdef" + Reset},
+	}
+
+	for _, tt := range tests {
+		if got := fitLine(tt.s, 6); got != tt.want {
+			t.Errorf("fitLine(%q) = %q, want %q", tt.s, got, tt.want)
+		}
+	}
+}
+
+// Read Key
+func TestReadKey(t *testing.T) {
+	reader := bufio.NewReader(strings.NewReader("j\x1b[A\x1b[6~\r\x04\x1b"))
+	for _, want := range []string{"j", "up", "pgdown", "enter", "ctrl-d", "esc"} {
+		got, err := readKey(reader)
+		if err != nil || got != want {
+			t.Errorf("readKey() = %q, %v, want %q", got, err, want)
+		}
+	}
+}
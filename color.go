@@ -0,0 +1,70 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+)
+
+// Colour modes accepted by --color.
+const (
+	colorAuto   = "auto"
+	colorAlways = "always"
+	colorNever  = "never"
+)
+
+// ansiReset is the sequence Reset holds while colour is on.
+const ansiReset = "\033[0m"
+
+var ansiPattern = regexp.MustCompile("\033\\[[0-9;]*m")
+
+// setColor switches Reset and every theme role on or off. All output is
+// built from these, so this is the one place colour is decided.
+func setColor(enabled bool) {
+	Reset = ""
+	if enabled {
+		Reset = ansiReset
+	}
+	colorOn = enabled
+	applyTheme()
+}
+
+// colorEnabled reports whether output written to f should be coloured.
+// In auto mode colour is used only when f is a terminal, NO_COLOR is unset
+// or empty and TERM is not "dumb".
+func colorEnabled(mode string, f *os.File) bool {
+	switch mode {
+	case colorAlways:
+		return true
+	case colorNever:
+		return false
+	}
+	if os.Getenv("NO_COLOR") != "" || os.Getenv("TERM") == "dumb" {
+		return false
+	}
+	return isTerminal(f)
+}
+
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
+
+// parseColorFlag checks the value of --color, returning "auto" when it was
+// not given.
+func parseColorFlag(mode string) (string, error) {
+	switch mode {
+	case "":
+		return colorAuto, nil
+	case colorAuto, colorAlways, colorNever:
+		return mode, nil
+	}
+	return "", withKind(ErrUsage, fmt.Errorf("invalid --color value \"%s\", want auto, always or never", mode))
+}
+
+func stripANSI(s string) string {
+	return ansiPattern.ReplaceAllString(s, "")
+}
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/bbarrington0099/gosyn/reference"
+)
+
+// Parse Color Flag
+func TestParseColorFlag(t *testing.T) {
+	tests := []struct {
+		name        string
+		value       string
+		wantMode    string
+		errContains string
+	}{
+		{"absent", "", colorAuto, ""},
+		{"never", "never", colorNever, ""},
+		{"always", "always", colorAlways, ""},
+		{"invalid", "sometimes", "", "invalid --color value"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mode, err := parseColorFlag(tt.value)
+			if tt.errContains != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errContains) {
+					t.Fatalf("parseColorFlag() error = %v, want contains %q", err, tt.errContains)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseColorFlag() error = %v", err)
+			}
+			if mode != tt.wantMode {
+				t.Errorf("parseColorFlag(%q) = %q, want %q", tt.value, mode, tt.wantMode)
+			}
+		})
+	}
+}
+
+// Color Enabled
+func TestColorEnabled(t *testing.T) {
+	file, err := os.Create(filepath.Join(t.TempDir(), "out"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+
+	tests := []struct {
+		name    string
+		mode    string
+		noColor string
+		want    bool
+	}{
+		{"always", colorAlways, "", true},
+		{"always beats NO_COLOR", colorAlways, "1", true},
+		{"never", colorNever, "", false},
+		{"auto on a file", colorAuto, "", false},
+		{"auto with NO_COLOR", colorAuto, "1", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("NO_COLOR", tt.noColor)
+			if got := colorEnabled(tt.mode, file); got != tt.want {
+				t.Errorf("colorEnabled(%q) = %v, want %v", tt.mode, got, tt.want)
+			}
+		})
+	}
+}
+
+// Set Color
+func TestSetColor(t *testing.T) {
+	defer setColor(true)
+
+	setColor(false)
+	output := listSections([]reference.Section{{Name: "Variables", Short: "var", Subsections: []reference.Subsection{{Name: "Types"}}}})
+	if strings.Contains(output, "\033[") {
+		t.Errorf("listSections() with colour off = %q, want no escape sequences", output)
+	}
+	if got := reference.RenderMarkup("{kw:for}", terminalRenderer); got != "for" {
+		t.Errorf("reference.RenderMarkup() with colour off = %q, want %q", got, "for")
+	}
+	_, err := tax(nil, "Missing", "")
+	if err == nil || strings.Contains(err.Error(), "\033[") {
+		t.Errorf("tax() error with colour off = %v, want no escape sequences", err)
+	}
+
+	setColor(true)
+	if Reset != "\033[0m" || style(roleError) != "\033[1;31m" {
+		t.Error("setColor(true) did not restore the styles")
+	}
+}
+
+func TestStripANSI(t *testing.T) {
+	if got := stripANSI(style(roleError) + "ERROR" + Reset + " message"); got != "ERROR message" {
+		t.Errorf("stripANSI() = %q", got)
+	}
+}
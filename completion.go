@@ -0,0 +1,198 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/bbarrington0099/gosyn/reference"
+)
+
+// completeCommand is the hidden command completion scripts call back into.
+// Its arguments are the words typed after "gosyn", the last being the
+// possibly empty word under the cursor; it prints one candidate per line.
+const completeCommand = "__complete"
+
+var completionScripts = map[string]string{
+	"bash": `# bash completion for gosyn
+_gosyn() {
+	local IFS=$'\n'
+	COMPREPLY=($(gosyn __complete "${COMP_WORDS[@]:1:COMP_CWORD}" 2>/dev/null))
+}
+complete -F _gosyn gosyn
+`,
+	"zsh": `#compdef gosyn
+# zsh completion for gosyn
+_gosyn() {
+	local -a candidates
+	candidates=(${(f)"$(gosyn __complete "${(@)words[2,CURRENT]}" 2>/dev/null)"})
+	compadd -a candidates
+}
+if [ "$funcstack[1]" = "_gosyn" ]; then
+	_gosyn "$@"
+else
+	compdef _gosyn gosyn
+fi
+`,
+	"fish": `# fish completion for gosyn
+function __gosyn_complete
+	set -l words (commandline -opc)
+	gosyn __complete $words[2..-1] (commandline -ct) 2>/dev/null
+end
+complete -c gosyn -f -a '(__gosyn_complete)'
+`,
+}
+
+func completionScript(shell string) (string, error) {
+	script, ok := completionScripts[shell]
+	if !ok {
+		return "", withKind(ErrUsage, fmt.Errorf("unsupported shell \"%s\", want bash, zsh or fish", shell))
+	}
+	return script, nil
+}
+
+// actionNames returns every command name and alias in commandDocs, so
+// completion follows the help text.
+func actionNames() []string {
+	var names []string
+	for _, doc := range commandDocs {
+		names = append(names, doc.names...)
+	}
+	return names
+}
+
+// commandFlags returns every spelling of the flags of the command name.
+func commandFlags(name string) []string {
+	doc, _ := findCommand(name)
+	return doc.flagNames()
+}
+
+func isAction(name string) bool {
+	return slices.ContainsFunc(actionNames(), func(action string) bool {
+		return strings.EqualFold(action, name)
+	})
+}
+
+// skipGlobalFlags drops the global flags words starts with, and their
+// values. When the last of them still needs a value, it is returned too.
+func skipGlobalFlags(words []string) ([]string, *flagDoc) {
+	for len(words) > 0 && isFlag(words[0]) {
+		name, _, hasValue := strings.Cut(words[0], "=")
+		i := slices.IndexFunc(globalFlags, func(flag flagDoc) bool {
+			return slices.Contains(flag.names, name)
+		})
+		if i < 0 {
+			break
+		}
+		n := 1
+		if globalFlags[i].value != "" && !hasValue {
+			if len(words) == 1 {
+				return nil, &globalFlags[i]
+			}
+			n = 2
+		}
+		words = words[n:]
+	}
+	return words, nil
+}
+
+// globalFlagValues returns the values a global flag can take, or nil when
+// they cannot be listed.
+func globalFlagValues(flag flagDoc) []string {
+	switch flag.key() {
+	case "--color":
+		return []string{colorAuto, colorAlways, colorNever}
+	case "--theme":
+		return themeNames()
+	case "--format":
+		return []string{formatText, formatJSON}
+	}
+	return nil
+}
+
+// complete returns the candidates for the last of words, the words typed
+// after "gosyn", keeping those starting with it, ignoring case. Global
+// flags before the command are skipped, and offered wherever a flag is
+// being typed.
+func complete(sections []reference.Section, words []string) []string {
+	if len(words) == 0 {
+		words = []string{""}
+	}
+	current := words[len(words)-1]
+	previous, pending := skipGlobalFlags(words[:len(words)-1])
+	if pending != nil {
+		return filterCandidates(globalFlagValues(*pending), current)
+	}
+
+	var candidates []string
+	switch {
+	case len(previous) > 0 && strings.EqualFold(previous[0], "gen-man"):
+		if previous[len(previous)-1] != "--out" {
+			candidates = commandFlags("gen-man")
+		}
+	case len(previous) > 0 && strings.EqualFold(previous[0], "export"):
+		switch previous[len(previous)-1] {
+		case "--format":
+			candidates = []string{exportMarkdown, exportHTML}
+		case "--out":
+			return nil
+		default:
+			candidates = commandFlags("export")
+		}
+	case len(previous) == 0:
+		candidates = actionNames()
+		for _, names := range reference.SectionNames(sections) {
+			candidates = append(candidates, names...)
+		}
+	case len(previous) == 1:
+		switch strings.ToLower(previous[0]) {
+		case "lsub", "listsubsections", "run":
+			for _, names := range reference.SectionNames(sections) {
+				candidates = append(candidates, names...)
+			}
+		case "completion":
+			candidates = []string{"bash", "fish", "zsh"}
+		case "s", "search":
+			candidates = commandFlags("search")
+		default:
+			if isAction(previous[0]) {
+				break
+			}
+			if i, _ := reference.MatchSection(sections, previous[0]); i >= 0 {
+				for _, names := range reference.SubsectionNames(sections[i]) {
+					candidates = append(candidates, names...)
+				}
+				candidates = append(append(candidates, "all"), lookupDoc().flagNames()...)
+			}
+		}
+	case len(previous) == 2 && strings.EqualFold(previous[0], "run"):
+		if i, _ := reference.MatchSection(sections, previous[1]); i >= 0 {
+			for _, sub := range sections[i].Subsections {
+				if sub.Kind == reference.KindCode {
+					candidates = append(candidates, sub.Name)
+				}
+			}
+		}
+	case len(previous) == 2:
+		if i, _ := reference.MatchSection(sections, previous[0]); i >= 0 && !isAction(previous[0]) {
+			candidates = lookupDoc().flagNames()
+		}
+	}
+	if strings.HasPrefix(current, "-") {
+		candidates = append(candidates, commandDoc{flags: globalFlags}.flagNames()...)
+	}
+
+	return filterCandidates(candidates, current)
+}
+
+// filterCandidates keeps the candidates starting with current, ignoring
+// case, dropping duplicates.
+func filterCandidates(candidates []string, current string) []string {
+	var matched []string
+	for _, candidate := range candidates {
+		if candidate != "" && strings.HasPrefix(strings.ToLower(candidate), strings.ToLower(current)) && !slices.Contains(matched, candidate) {
+			matched = append(matched, candidate)
+		}
+	}
+	return matched
+}
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/bbarrington0099/gosyn/reference"
+)
+
+// Action Names
+func TestActionNames(t *testing.T) {
+	names := actionNames()
+	for _, want := range []string{"help", "h", "listSections", "lsec", "listSubsections", "lsub", "search", "s", "completion", "export", "gen-man"} {
+		if !strings.Contains(" "+strings.Join(names, " ")+" ", " "+want+" ") {
+			t.Errorf("actionNames() = %v, missing %q", names, want)
+		}
+	}
+	for _, name := range names {
+		if strings.HasPrefix(name, "<") || name == completeCommand {
+			t.Errorf("actionNames() = %v, should not contain %q", names, name)
+		}
+	}
+}
+
+// Complete
+func TestComplete(t *testing.T) {
+	sections := []reference.Section{
+		{Name: "Variables", Short: "var", Subsections: []reference.Subsection{{Name: "Declaration"}, {Name: "Types"}}},
+		{Name: "Functions", Short: "func", Subsections: []reference.Subsection{{Name: "Declaration"}, {Name: "Closures", Kind: reference.KindCode}}},
+	}
+
+	tests := []struct {
+		name  string
+		words []string
+		want  []string
+	}{
+		{"action prefix", []string{"l"}, []string{"listSections", "lsec", "listSubsections", "lsub", "lint"}},
+		{"section prefix ignores case", []string{"FU"}, []string{"Functions", "func"}},
+		{"subsections of typed section", []string{"var", ""}, []string{"Declaration", "Types", "all", "-p", "--pager"}},
+		{"subsections of partly typed section", []string{"Func", "c"}, []string{"Closures"}},
+		{"listSubsections takes a section", []string{"lsub", "v"}, []string{"Variables", "var"}},
+		{"completion shells", []string{"completion", ""}, []string{"bash", "fish", "zsh"}},
+		{"run takes a section", []string{"run", "f"}, []string{"Functions", "func"}},
+		{"run only offers code", []string{"run", "func", ""}, []string{"Closures"}},
+		{"export flags", []string{"export", ""}, []string{"--format", "--out"}},
+		{"export formats", []string{"export", "--out", "docs", "--format", ""}, []string{"markdown", "html"}},
+		{"flag after subsection", []string{"var", "Types", "--"}, []string{"--pager", "--color", "--theme", "--format", "--go", "--strict", "--help"}},
+		{"global flag names", []string{"--co"}, []string{"--color"}},
+		{"global flag values", []string{"--color", "a"}, []string{"auto", "always"}},
+		{"theme names", []string{"--theme", "solarized-"}, []string{"solarized-256", "solarized-truecolor"}},
+		{"after global flags", []string{"--theme", "light", "--strict", "lsub", "v"}, []string{"Variables", "var"}},
+		{"after a global flag with =", []string{"--format=json", "var", ""}, []string{"Declaration", "Types", "all", "-p", "--pager"}},
+		{"nothing after help", []string{"help", ""}, nil},
+		{"unknown section", []string{"Nope", ""}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := complete(sections, tt.words); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("complete(%q) = %q, want %q", tt.words, got, tt.want)
+			}
+		})
+	}
+}
+
+// Completion Script
+func TestCompletionScript(t *testing.T) {
+	for _, shell := range []string{"bash", "zsh", "fish"} {
+		script, err := completionScript(shell)
+		if err != nil || !strings.Contains(script, "gosyn "+completeCommand) {
+			t.Errorf("completionScript(%q) = %q, %v, want a script calling back into gosyn", shell, script, err)
+		}
+	}
+	if _, err := completionScript("tcsh"); err == nil || !strings.Contains(err.Error(), "unsupported shell") {
+		t.Errorf("completionScript(\"tcsh\") error = %v, want unsupported shell", err)
+	}
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Warning codes, reported with --format json like error codes.
+const (
+	warnExtraArguments = "extra_arguments" // arguments past the last one a command takes
+	warnConfig         = "config"          // the config file is unreadable or has unknown keys
+	warnUserSections   = "user_sections"   // user-defined sections could not be loaded
+	warnHistory        = "history"         // the interactive prompt's history could not be saved
+)
+
+// diagnostic is a warning reported while a command runs.
+type diagnostic struct {
+	code    string // one of the warn* codes
+	message string
+}
+
+// diagnostics collects the warnings of the current command. They are not
+// printed as they happen, so they never mix with the command's output and
+// can be written as one JSON document with --format json.
+var diagnostics []diagnostic
+
+// warn records a warning, formatting its message like fmt.Sprintf.
+func warn(code string, format string, args ...any) {
+	diagnostics = append(diagnostics, diagnostic{code: code, message: fmt.Sprintf(format, args...)})
+}
+
+// takeDiagnostics returns the warnings recorded so far and forgets them.
+func takeDiagnostics() []diagnostic {
+	taken := diagnostics
+	diagnostics = nil
+	return taken
+}
+
+// formatDiagnostics renders warnings for stderr: one "WARNING message"
+// line each, styled when styled is set, or with --format json a
+// {"warnings": [{"code", "message"}]} document. It returns "" when there
+// are none.
+func formatDiagnostics(warnings []diagnostic, styled bool) string {
+	if len(warnings) == 0 {
+		return ""
+	}
+	if outputFormat == formatJSON {
+		output, _ := toJSON(warningsJSON{Warnings: diagnosticsJSON(warnings)})
+		return output
+	}
+	var lines []string
+	for _, w := range warnings {
+		if styled {
+			lines = append(lines, fmt.Sprintf("%sWARNING%s %s", style(roleWarning), Reset, w.message))
+		} else {
+			lines = append(lines, "WARNING "+w.message)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// writeDiagnostics writes the warnings recorded so far to w, styled when
+// styled is set, and forgets them.
+func writeDiagnostics(w io.Writer, styled bool) {
+	if output := formatDiagnostics(takeDiagnostics(), styled); output != "" {
+		fmt.Fprintln(w, output)
+	}
+}
+
+// strictError fails a --strict run that reported count warnings, which
+// fatal writes before it.
+func strictError(count int) error {
+	return withKind(ErrStrict, fmt.Errorf("%d warning(s) reported with --strict", count))
+}
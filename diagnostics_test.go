@@ -0,0 +1,69 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+// Warn
+func TestWarn(t *testing.T) {
+	defer takeDiagnostics()
+
+	if _, err := parseCommand([]string{"lsub", "var", "extra"}); err != nil {
+		t.Fatalf("parseCommand() error = %v", err)
+	}
+	warnings := takeDiagnostics()
+	if len(warnings) != 1 || warnings[0].code != warnExtraArguments || !strings.Contains(warnings[0].message, "[extra]") {
+		t.Fatalf("parseCommand() with an extra argument warned %+v, want one %s warning", warnings, warnExtraArguments)
+	}
+	if len(takeDiagnostics()) != 0 {
+		t.Error("takeDiagnostics() should forget the warnings it returns")
+	}
+
+	if got := formatDiagnostics(warnings, false); got != "WARNING "+warnings[0].message {
+		t.Errorf("formatDiagnostics() = %q, want a WARNING line", got)
+	}
+	if got := formatDiagnostics(warnings, true); !strings.HasPrefix(got, style(roleWarning)+"WARNING"+Reset) {
+		t.Errorf("formatDiagnostics() styled = %q, want a styled WARNING", got)
+	}
+	if got := formatDiagnostics(nil, true); got != "" {
+		t.Errorf("formatDiagnostics(nil) = %q, want nothing", got)
+	}
+
+	var out bytes.Buffer
+	warn(warnConfig, "unknown config key \"%s\"", "colour")
+	writeDiagnostics(&out, false)
+	if got := out.String(); got != "WARNING unknown config key \"colour\"\n" {
+		t.Errorf("writeDiagnostics() wrote %q", got)
+	}
+}
+
+// Warnings JSON
+func TestWarningsJSON(t *testing.T) {
+	defer func() { outputFormat = formatText }()
+	outputFormat = formatJSON
+	warnings := []diagnostic{{code: warnConfig, message: "ignoring config file: permission denied"}}
+
+	var doc warningsJSON
+	if err := json.Unmarshal([]byte(formatDiagnostics(warnings, true)), &doc); err != nil {
+		t.Fatalf("formatDiagnostics() is not JSON: %v", err)
+	}
+	if len(doc.Warnings) != 1 || doc.Warnings[0] != (diagnosticJSON{Code: warnConfig, Message: warnings[0].message}) {
+		t.Errorf("formatDiagnostics() = %+v", doc)
+	}
+
+	var errorDoc errorJSON
+	err := strictError(len(warnings))
+	if err := json.Unmarshal([]byte(formatErrorJSON(err, warnings)), &errorDoc); err != nil {
+		t.Fatalf("formatErrorJSON() is not JSON: %v", err)
+	}
+	if errorDoc.Error.Code != codeStrict || len(errorDoc.Warnings) != 1 {
+		t.Errorf("formatErrorJSON() = %+v, want the strict error and its warning", errorDoc)
+	}
+	if !errors.Is(err, ErrStrict) || exitStatus(err) != 10 {
+		t.Errorf("strictError() = %v with status %d, want ErrStrict and status 10", err, exitStatus(err))
+	}
+}
@@ -0,0 +1,109 @@
+package main
+
+import "errors"
+
+// Kinds of error, checked with errors.Is. Every error a command returns
+// wraps at most one of them, see withKind.
+var (
+	ErrUsage              = errors.New("usage error")
+	ErrSectionNotFound    = errors.New("section not found")
+	ErrSubsectionNotFound = errors.New("subsection not found")
+	ErrAmbiguous          = errors.New("ambiguous name")
+	ErrInvalidQuery       = errors.New("invalid query")
+	ErrTerminal           = errors.New("terminal unavailable")
+	ErrLint               = errors.New("lint problems found")
+	ErrNotRunnable        = errors.New("not runnable")
+	ErrStrict             = errors.New("warnings reported with --strict")
+	ErrWrite              = errors.New("output not written")
+)
+
+// Error codes reported with --format json, so scripts can tell failures
+// apart without parsing messages.
+const (
+	codeUsage        = "usage"         // missing, extra or invalid arguments or flags
+	codeNotFound     = "not_found"     // no section or subsection by that name
+	codeAmbiguous    = "ambiguous"     // the name fits several sections or subsections
+	codeInvalidQuery = "invalid_query" // a search regular expression does not compile
+	codeTerminal     = "terminal"      // an interactive command could not use the terminal
+	codeLint         = "lint"          // lint found problems in the rendered content
+	codeNotRunnable  = "not_runnable"  // run was given a template, or there is no go toolchain
+	codeStrict       = "strict"        // --strict was given and warnings were reported
+	codeWrite        = "write"         // export or gen-man could not write its files
+	codeInternal     = "internal"      // anything else
+)
+
+// exitInternal is the exit status for errors of no known kind.
+const exitInternal = 1
+
+// errorKinds gives every kind of error its JSON code and exit status. The
+// statuses are documented in the README and gosyn(1) and must not change.
+var errorKinds = []struct {
+	kind   error
+	code   string
+	status int
+	desc   string
+}{
+	{ErrUsage, codeUsage, 2, "usage error: an unknown flag, or a missing or invalid argument"},
+	{ErrSectionNotFound, codeNotFound, 3, "section not found"},
+	{ErrSubsectionNotFound, codeNotFound, 4, "subsection not found"},
+	{ErrAmbiguous, codeAmbiguous, 5, "the name fits several sections or subsections"},
+	{ErrInvalidQuery, codeInvalidQuery, 6, "invalid search regular expression"},
+	{ErrTerminal, codeTerminal, 7, "an interactive command could not use the terminal"},
+	{ErrLint, codeLint, 8, "lint found problems"},
+	{ErrNotRunnable, codeNotRunnable, 9, "the snippet cannot be run"},
+	{ErrStrict, codeStrict, 10, "warnings were reported with --strict"},
+	{ErrWrite, codeWrite, 11, "export or gen-man could not write its files"},
+}
+
+// kindError attaches a kind to an error without changing its message.
+type kindError struct {
+	kind error
+	err  error
+}
+
+func (e *kindError) Error() string {
+	return e.err.Error()
+}
+
+func (e *kindError) Unwrap() error {
+	return e.err
+}
+
+func (e *kindError) Is(target error) bool {
+	return target == e.kind
+}
+
+func withKind(kind error, err error) error {
+	return &kindError{kind: kind, err: err}
+}
+
+// errorCode returns the JSON code for err's kind, or codeInternal when it
+// has none.
+func errorCode(err error) string {
+	for _, k := range errorKinds {
+		if errors.Is(err, k.kind) {
+			return k.code
+		}
+	}
+	return codeInternal
+}
+
+// exitStatus returns the exit status for err's kind, or exitInternal when
+// it has none.
+func exitStatus(err error) int {
+	for _, k := range errorKinds {
+		if errors.Is(err, k.kind) {
+			return k.status
+		}
+	}
+	return exitInternal
+}
+
+// describeError is how err is shown as text: its message after "ERROR",
+// styled when styled is set.
+func describeError(err error, styled bool) string {
+	if !styled {
+		return "ERROR " + err.Error()
+	}
+	return style(roleError) + "ERROR" + Reset + " " + err.Error()
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/bbarrington0099/gosyn/reference"
+)
+
+// Error Kinds
+func TestErrorKinds(t *testing.T) {
+	sections := []reference.Section{
+		{Name: "Variables", Subsections: []reference.Subsection{{Name: "Declaration"}}},
+		{Name: "Values", Subsections: []reference.Subsection{{Name: "Declaration"}}},
+	}
+
+	tests := []struct {
+		name       string
+		args       []string
+		wantKind   error
+		wantStatus int
+	}{
+		{"usage", []string{"lsub"}, ErrUsage, 2},
+		{"unknown section", []string{"Nope", "Declaration"}, ErrSectionNotFound, 3},
+		{"unknown lone name", []string{"Nope"}, ErrSectionNotFound, 3},
+		{"unknown subsection", []string{"Variables", "Nope"}, ErrSubsectionNotFound, 4},
+		{"ambiguous section", []string{"lsub", "va"}, ErrAmbiguous, 5},
+		{"invalid query", []string{"s", "-r", "("}, ErrInvalidQuery, 6},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := runArgs(sections, tt.args)
+			if !errors.Is(err, tt.wantKind) {
+				t.Fatalf("runArgs(%q) error = %v, want %v", tt.args, err, tt.wantKind)
+			}
+			if status := exitStatus(err); status != tt.wantStatus {
+				t.Errorf("exitStatus() = %d, want %d", status, tt.wantStatus)
+			}
+		})
+	}
+
+	plain := errors.New("plain")
+	if errors.Is(plain, ErrUsage) || exitStatus(plain) != exitInternal {
+		t.Errorf("exitStatus() of an error of no kind = %d, want %d", exitStatus(plain), exitInternal)
+	}
+	wrapped := fmt.Errorf("wrapped: %w", withKind(ErrLint, plain))
+	if !errors.Is(wrapped, ErrLint) || !errors.Is(wrapped, plain) || exitStatus(wrapped) != 8 {
+		t.Errorf("withKind() wrapped in another error lost its kind or cause")
+	}
+}
+
+// Describe Error
+func TestDescribeError(t *testing.T) {
+	err := withKind(ErrUsage, errors.New("no query provided"))
+	if got := describeError(err, false); got != "ERROR no query provided" {
+		t.Errorf("describeError() = %q, want %q", got, "ERROR no query provided")
+	}
+	if got, want := describeError(err, true), style(roleError)+"ERROR"+Reset+" no query provided"; got != want {
+		t.Errorf("describeError() styled = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,228 @@
+package main
+
+import (
+	"fmt"
+	"html"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/bbarrington0099/gosyn/reference"
+)
+
+// Formats accepted by export --format.
+const (
+	exportMarkdown = "markdown"
+	exportHTML     = "html"
+)
+
+// parseExportFormat checks the value of export's --format, markdown being
+// the default.
+func parseExportFormat(format string) (string, error) {
+	switch strings.ToLower(format) {
+	case "", "md", exportMarkdown:
+		return exportMarkdown, nil
+	case exportHTML:
+		return exportHTML, nil
+	}
+	return "", withKind(ErrUsage, fmt.Errorf("invalid export --format value \"%s\", want markdown or html", format))
+}
+
+// exportSections writes the reference to dir in format: an index with a
+// table of contents linking to one document per section, in which every
+// subsection has its own anchor. It returns the paths written.
+func exportSections(sections []reference.Section, format string, dir string) ([]string, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, withKind(ErrWrite, fmt.Errorf("cannot write the export: %w", err))
+	}
+	documents := map[string]string{}
+	switch format {
+	case exportHTML:
+		documents["index.html"] = indexHTML(sections)
+		for _, sec := range sections {
+			documents[sectionFile(sec, format)] = sectionHTML(sec)
+		}
+	default:
+		documents["index.md"] = indexMarkdown(sections)
+		for _, sec := range sections {
+			documents[sectionFile(sec, format)] = sectionMarkdown(sec)
+		}
+	}
+
+	var paths []string
+	for name, document := range documents {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(document), 0o644); err != nil {
+			return nil, withKind(ErrWrite, fmt.Errorf("cannot write the export: %w", err))
+		}
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	return paths, nil
+}
+
+// exportSummary reports what exportSections wrote.
+func exportSummary(sections []reference.Section, format string, dir string, paths []string) string {
+	return fmt.Sprintf("%sExported%s %d sections as %s%s%s to %s%s%s (%d files)",
+		style(roleHeading), Reset, // Exported
+		len(sections),
+		style(roleArg), format, Reset, // format
+		style(roleArg), dir, Reset, // dir
+		len(paths),
+	)
+}
+
+// anchor turns a name into the lower-case, hyphenated form used for file
+// names and fragment identifiers.
+func anchor(name string) string {
+	var out strings.Builder
+	for _, c := range strings.TrimSpace(name) {
+		switch {
+		case unicode.IsLetter(c) || unicode.IsDigit(c):
+			out.WriteRune(unicode.ToLower(c))
+		case c == '-' || c == '_' || c == ' ':
+			out.WriteRune('-')
+		}
+	}
+	return out.String()
+}
+
+func sectionFile(sec reference.Section, format string) string {
+	if format == exportHTML {
+		return anchor(sec.Name) + ".html"
+	}
+	return anchor(sec.Name) + ".md"
+}
+
+func exportLang(sub reference.Subsection) string {
+	if sub.Lang == "" {
+		return "go"
+	}
+	return sub.Lang
+}
+
+func indexMarkdown(sections []reference.Section) string {
+	var out strings.Builder
+	out.WriteString("# Go Syntax Quick Reference\n\n")
+	for _, sec := range sections {
+		file := sectionFile(sec, exportMarkdown)
+		fmt.Fprintf(&out, "- [%s](%s)\n", sec.Name, file)
+		for _, sub := range sec.Subsections {
+			fmt.Fprintf(&out, "  - [%s](%s#%s)\n", sub.Name, file, anchor(sub.Name))
+		}
+	}
+	return out.String()
+}
+
+func sectionMarkdown(sec reference.Section) string {
+	var out strings.Builder
+	fmt.Fprintf(&out, "[Index](index.md)\n\n# %s\n\n", sec.Name)
+	for _, sub := range sec.Subsections {
+		fmt.Fprintf(&out, "- [%s](#%s)\n", sub.Name, anchor(sub.Name))
+	}
+	for _, sub := range sec.Subsections {
+		content := strings.TrimRight(reference.Render(sub, reference.Plain), "\n")
+		fence := markdownFence(content)
+		fmt.Fprintf(&out, "\n<a id=\"%s\"></a>\n\n## %s\n\n%s%s\n%s\n%s\n", anchor(sub.Name), sub.Name, fence, exportLang(sub), content, fence)
+	}
+	return out.String()
+}
+
+// markdownFence returns a code fence longer than any run of backticks in
+// content, so content cannot close it early.
+func markdownFence(content string) string {
+	longest, run := 0, 0
+	for _, c := range content {
+		if c == '`' {
+			run++
+			longest = max(longest, run)
+		} else {
+			run = 0
+		}
+	}
+	return strings.Repeat("`", max(3, longest+1))
+}
+
+func indexHTML(sections []reference.Section) string {
+	var body strings.Builder
+	body.WriteString("<h1>Go Syntax Quick Reference</h1>\n<nav>\n<ul>\n")
+	for _, sec := range sections {
+		file := sectionFile(sec, exportHTML)
+		fmt.Fprintf(&body, "<li><a href=\"%s\">%s</a>\n<ul>\n", file, html.EscapeString(sec.Name))
+		for _, sub := range sec.Subsections {
+			fmt.Fprintf(&body, "<li><a href=\"%s#%s\">%s</a></li>\n", file, anchor(sub.Name), html.EscapeString(sub.Name))
+		}
+		body.WriteString("</ul>\n</li>\n")
+	}
+	body.WriteString("</ul>\n</nav>\n")
+	return htmlDocument("Go Syntax Quick Reference", body.String())
+}
+
+func sectionHTML(sec reference.Section) string {
+	var body strings.Builder
+	fmt.Fprintf(&body, "<p><a href=\"index.html\">Index</a></p>\n<h1>%s</h1>\n<nav>\n<ul>\n", html.EscapeString(sec.Name))
+	for _, sub := range sec.Subsections {
+		fmt.Fprintf(&body, "<li><a href=\"#%s\">%s</a></li>\n", anchor(sub.Name), html.EscapeString(sub.Name))
+	}
+	body.WriteString("</ul>\n</nav>\n")
+	for _, sub := range sec.Subsections {
+		content := strings.TrimRight(reference.Render(sub, reference.HTML), "\n")
+		fmt.Fprintf(&body, "<h2 id=\"%s\">%s</h2>\n<pre class=\"%s\"><code>%s</code></pre>\n", anchor(sub.Name), html.EscapeString(sub.Name), exportLang(sub), content)
+	}
+	return htmlDocument(sec.Name, body.String())
+}
+
+func htmlDocument(title string, body string) string {
+	return fmt.Sprintf("<!DOCTYPE html>\n<html lang=\"en\">\n<head>\n<meta charset=\"utf-8\">\n<title>%s</title>\n<style>\n%s</style>\n</head>\n<body>\n%s</body>\n</html>\n",
+		html.EscapeString(title), themeCSS(activeTheme), body)
+}
+
+// themeCSS styles the spans reference.HTML writes like the terminal theme t
+// styles the same roles, on a background that suits the theme's colours.
+func themeCSS(t theme) string {
+	var out strings.Builder
+	background, foreground := "#ffffff", "#1c1c1c"
+	if t.forDarkBackground() {
+		background, foreground = "#1c1c1c", "#e4e4e4"
+	}
+	fmt.Fprintf(&out, "pre { background: %s; color: %s; padding: 1em; overflow-x: auto; tab-size: 4; }\n", background, foreground)
+
+	for _, role := range reference.Roles() {
+		var declarations []string
+		s := t[role]
+		for _, attr := range strings.Split(s.attrs, ";") {
+			switch attr {
+			case "1":
+				declarations = append(declarations, "font-weight: bold")
+			case "2":
+				declarations = append(declarations, "opacity: 0.7")
+			case "3":
+				declarations = append(declarations, "font-style: italic")
+			case "4":
+				declarations = append(declarations, "text-decoration: underline")
+			}
+		}
+		if s.hex != "" {
+			declarations = append(declarations, "color: "+s.hex)
+		}
+		if len(declarations) > 0 {
+			fmt.Fprintf(&out, "pre .%s { %s; }\n", role, strings.Join(declarations, "; "))
+		}
+	}
+	return out.String()
+}
+
+// forDarkBackground reports whether t's colours are on average light,
+// meaning they were picked for a dark terminal background.
+func (t theme) forDarkBackground() bool {
+	total, count := 0, 0
+	for _, s := range t {
+		if r, g, b, ok := parseHex(s.hex); ok {
+			total += (2126*r + 7152*g + 722*b) / 10000
+			count++
+		}
+	}
+	return count > 0 && total/count > 128
+}
@@ -0,0 +1,121 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/bbarrington0099/gosyn/reference"
+)
+
+// Parse Export Format
+func TestParseExportFormat(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    string
+		wantErr bool
+	}{
+		{"", exportMarkdown, false},
+		{"html", exportHTML, false},
+		{"md", exportMarkdown, false},
+		{"pdf", "", true},
+	}
+
+	for _, tt := range tests {
+		format, err := parseExportFormat(tt.value)
+		if (err != nil) != tt.wantErr || format != tt.want {
+			t.Errorf("parseExportFormat(%q) = %q, %v", tt.value, format, err)
+		}
+		if err != nil && errorCode(err) != codeUsage {
+			t.Errorf("parseExportFormat(%q) error code = %q, want %q", tt.value, errorCode(err), codeUsage)
+		}
+	}
+}
+
+// Export Sections
+func TestExportSections(t *testing.T) {
+	sections := []reference.Section{
+		{Name: "Variables", Short: "var", Subsections: []reference.Subsection{{Name: "Declaration", Content: "{title:Declaration}:\n\tvar x = \"<ok>\""}}},
+		{Name: "BuildRun", Short: "build", Subsections: []reference.Subsection{{Name: "Commands", Lang: "sh", Content: "go build"}}},
+	}
+
+	tests := []struct {
+		format string
+		files  []string
+		checks map[string][]string
+	}{
+		{
+			format: exportMarkdown,
+			files:  []string{"buildrun.md", "index.md", "variables.md"},
+			checks: map[string][]string{
+				"index.md":     {"- [Variables](variables.md)\n", "  - [Declaration](variables.md#declaration)\n", "  - [Commands](buildrun.md#commands)\n"},
+				"variables.md": {"[Index](index.md)", "- [Declaration](#declaration)", "<a id=\"declaration\"></a>\n\n## Declaration\n\n```go\nDeclaration:\n\tvar x = \"<ok>\"\n```\n"},
+				"buildrun.md":  {"```sh\ngo build\n```"},
+			},
+		},
+		{
+			format: exportHTML,
+			files:  []string{"buildrun.html", "index.html", "variables.html"},
+			checks: map[string][]string{
+				"index.html":     {"<a href=\"variables.html#declaration\">Declaration</a>", "<style>"},
+				"variables.html": {"<h2 id=\"declaration\">Declaration</h2>", "<pre class=\"go\"><code><span class=\"title\">Declaration</span>", "&lt;ok&gt;", "pre .kw {"},
+				"buildrun.html":  {"<pre class=\"sh\"><code>go build</code></pre>"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			dir := filepath.Join(t.TempDir(), "out")
+			paths, err := exportSections(sections, tt.format, dir)
+			if err != nil {
+				t.Fatalf("exportSections() error = %v", err)
+			}
+			var files []string
+			for _, path := range paths {
+				files = append(files, filepath.Base(path))
+			}
+			if !reflect.DeepEqual(files, tt.files) {
+				t.Errorf("exportSections() wrote %q, want %q", files, tt.files)
+			}
+			for file, wants := range tt.checks {
+				data, err := os.ReadFile(filepath.Join(dir, file))
+				if err != nil {
+					t.Fatal(err)
+				}
+				for _, want := range wants {
+					if !strings.Contains(string(data), want) {
+						t.Errorf("%s should contain %q, got:\n%s", file, want, data)
+					}
+				}
+			}
+		})
+	}
+}
+
+// Markdown Fence
+func TestMarkdownFence(t *testing.T) {
+	if got := markdownFence("plain"); got != "```" {
+		t.Errorf("markdownFence(plain) = %q, want ```", got)
+	}
+	if got := markdownFence("a ```` b"); got != "`````" {
+		t.Errorf("markdownFence(4 backticks) = %q, want 5", got)
+	}
+}
+
+// Theme CSS
+func TestThemeCSS(t *testing.T) {
+	dark := themeCSS(themes["dark"])
+	if !strings.Contains(dark, "background: #1c1c1c") || !strings.Contains(dark, "pre .kw { color: #5fd7d7; }") {
+		t.Errorf("themeCSS(dark) = %s", dark)
+	}
+	light := themeCSS(themes["light"])
+	if !strings.Contains(light, "background: #ffffff") || !strings.Contains(light, "pre .ph { font-weight: bold; color: #af00af; }") {
+		t.Errorf("themeCSS(light) = %s", light)
+	}
+	if strings.Contains(dark, ".section") {
+		t.Errorf("themeCSS() should only style markup roles, got %s", dark)
+	}
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/bbarrington0099/gosyn/reference"
+)
+
+// targetGo is the Go release content is shown for, set by --go or the
+// nearest go.mod; empty when neither gives one, and everything is shown.
+var targetGo = ""
+
+// parseGoFlag checks the value of --go, falling back to the go directive
+// of the nearest go.mod above the working directory when it was not given.
+func parseGoFlag(goVersion string) (string, error) {
+	if goVersion == "" {
+		dir, err := os.Getwd()
+		if err != nil {
+			return "", nil
+		}
+		return goModVersion(dir), nil
+	}
+	if !reference.ValidGoVersion(goVersion) {
+		return "", withKind(ErrUsage, fmt.Errorf("invalid --go value \"%s\", want a Go release such as 1.22", goVersion))
+	}
+	return strings.TrimPrefix(goVersion, "go"), nil
+}
+
+// goModVersion returns the go directive of the go.mod in dir or the
+// closest of its parents that has one, or "" when there is none.
+func goModVersion(dir string) string {
+	for {
+		if data, err := os.ReadFile(filepath.Join(dir, "go.mod")); err == nil {
+			for _, line := range strings.Split(string(data), "\n") {
+				fields := strings.Fields(line)
+				if len(fields) == 2 && fields[0] == "go" && reference.ValidGoVersion(fields[1]) {
+					return fields[1]
+				}
+			}
+			return ""
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+		dir = parent
+	}
+}
+
+// goNote marks sub in a heading with the Go releases it applies to, in the
+// warning style when targetGo is not one of them.
+func goNote(sub reference.Subsection) string {
+	goRange := sub.GoRange()
+	switch {
+	case goRange == "":
+		return ""
+	case !sub.Supports(targetGo):
+		return fmt.Sprintf(" %s(%s, not Go %s)%s", style(roleWarning), goRange, targetGo, Reset)
+	}
+	return fmt.Sprintf(" %s(%s)%s", style(roleNote), goRange, Reset)
+}
+
+// supportedSubsections splits the subsections of sec into those targetGo
+// supports and the names of those it does not.
+func supportedSubsections(sec reference.Section) ([]reference.Subsection, []string) {
+	var supported []reference.Subsection
+	var hidden []string
+	for _, sub := range sec.Subsections {
+		if sub.Supports(targetGo) {
+			supported = append(supported, sub)
+		} else {
+			hidden = append(hidden, sub.Name)
+		}
+	}
+	return supported, hidden
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/bbarrington0099/gosyn/reference"
+)
+
+// Parse Go Flag
+func TestParseGoFlag(t *testing.T) {
+	for value, want := range map[string]string{"1.20": "1.20", "go1.22.3": "1.22.3"} {
+		if got, err := parseGoFlag(value); err != nil || got != want {
+			t.Errorf("parseGoFlag(%q) = %q, %v, want %q", value, got, err, want)
+		}
+	}
+	if _, err := parseGoFlag("latest"); errorCode(err) != codeUsage {
+		t.Errorf("parseGoFlag(latest) error = %v, want a usage error", err)
+	}
+}
+
+// Go Mod Version
+func TestGoModVersion(t *testing.T) {
+	root := t.TempDir()
+	nested := filepath.Join(root, "cmd", "app")
+	if err := os.MkdirAll(nested, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if got := goModVersion(nested); got != "" {
+		t.Errorf("goModVersion() without a go.mod = %q, want none", got)
+	}
+
+	goMod := "module example.com/app\n\ngo 1.21.0\n\ntoolchain go1.22.1\n"
+	if err := os.WriteFile(filepath.Join(root, "go.mod"), []byte(goMod), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if got := goModVersion(nested); got != "1.21.0" {
+		t.Errorf("goModVersion() = %q, want the go directive of the parent go.mod", got)
+	}
+}
+
+// Target Go
+func TestTargetGo(t *testing.T) {
+	defer func() { targetGo = "" }()
+	sec := reference.Section{
+		Name: "Loops",
+		Subsections: []reference.Subsection{
+			{Name: "For", Content: "for {}"},
+			{Name: "RangeOverInt", MinGo: "1.22", Content: "for range 3 {}"},
+		},
+	}
+	sections := []reference.Section{sec}
+
+	targetGo = "1.22"
+	output, err := tax(sections, "Loops", "RangeOverInt")
+	if err != nil || !strings.Contains(stripANSI(output), "RangeOverInt in Loops (Go 1.22+):") {
+		t.Errorf("tax() = %q, %v, want the Go releases in the header", output, err)
+	}
+
+	targetGo = "1.20"
+	output, err = tax(sections, "Loops", "RangeOverInt")
+	if err != nil || !strings.Contains(stripANSI(output), "(Go 1.22+, not Go 1.20):") {
+		t.Errorf("tax() = %q, %v, want the snippet marked as unsupported", output, err)
+	}
+	output, err = listSubsections(sections, "Loops")
+	if err != nil || !strings.Contains(stripANSI(output), "   - RangeOverInt (Go 1.22+, not Go 1.20)\n") {
+		t.Errorf("listSubsections() = %q, %v, want the subsection marked as unsupported", output, err)
+	}
+	output = stripANSI(taxSection(sec))
+	if strings.Contains(output, "for range 3") || !strings.Contains(output, "for {}") || !strings.Contains(output, "Hidden for Go 1.20, see --go: RangeOverInt\n") {
+		t.Errorf("taxSection() = %q, want RangeOverInt hidden and named", output)
+	}
+}
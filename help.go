@@ -0,0 +1,219 @@
+package main
+
+import (
+	"regexp"
+	"strings"
+)
+
+// commandDoc defines a command. The table below is the one description of
+// the command set: parseCommand reads arguments and flags by it, and
+// listActions, --help, the gosyn(1) man page and completion are all built
+// from it.
+type commandDoc struct {
+	names   []string // name then aliases; none for the section lookup
+	args    []argDoc // positional arguments, in order
+	flags   []flagDoc
+	summary string // one line, in doc markup
+}
+
+// argDoc defines a positional argument; desc is in doc markup.
+type argDoc struct {
+	name     string // as written in usage, such as "<sectionName>"
+	what     string // what it is called when missing, such as "section name"
+	optional bool
+	rest     bool // takes every remaining argument, joined by spaces
+	desc     string
+}
+
+// flagDoc defines a flag; desc is in doc markup. A flag with a value is
+// given as "--flag value" or "--flag=value", a boolean flag alone.
+type flagDoc struct {
+	names    []string // spellings, the last being the one its value is stored under
+	value    string   // placeholder for the value, such as "<dir>"; empty for a boolean flag
+	required bool
+	desc     string
+}
+
+// optionDoc is a line explaining an argument, flag or file; desc is in doc
+// markup.
+type optionDoc struct {
+	name string
+	desc string
+}
+
+var commandDocs = []commandDoc{
+	{names: []string{"help", "h"}, summary: "List all available commands"},
+	{names: []string{"listSections", "lsec"}, summary: "List all sections"},
+	{
+		names:   []string{"listSubsections", "lsub"},
+		args:    []argDoc{{name: "<sectionName>", what: "section name", desc: "is the name of the section to list subsections for"}},
+		summary: "List all subsections in a section",
+	},
+	{
+		names:   []string{"search", "s"},
+		args:    []argDoc{{name: "<query>", what: "query", rest: true}},
+		flags:   []flagDoc{{names: []string{"-r", "--regex"}, desc: "treats {arg:<query>} as a regular expression"}},
+		summary: "Search all snippets for a token",
+	},
+	{
+		names:   []string{"completion"},
+		args:    []argDoc{{name: "<bash | zsh | fish>", what: "shell"}},
+		summary: "Print a shell completion script",
+	},
+	{names: []string{"repl"}, summary: "Start an interactive prompt, also opened by a bare {command:gosyn} on a terminal"},
+	{names: []string{"browse"}, summary: "Browse sections and subsections in a full-screen two-pane view"},
+	{
+		names: []string{"export"},
+		flags: []flagDoc{
+			{names: []string{"--format"}, value: "<markdown | html>", desc: "is the document format, Markdown by default; HTML is coloured like the current theme"},
+			{names: []string{"--out"}, value: "<dir>", required: true, desc: "is the directory the index and a document per section are written to"},
+		},
+		summary: "Write the whole reference as linked documents",
+	},
+	{
+		names:   []string{"gen-man"},
+		flags:   []flagDoc{{names: []string{"--out"}, value: "<dir>", required: true, desc: "is a man directory such as {arg:~/.local/share/man}, the pages go in its man1 and man7"}},
+		summary: "Write the gosyn(1) and gosyn-syntax(7) man pages",
+	},
+	{
+		names: []string{"run"},
+		args: []argDoc{
+			{name: "<sectionName>", what: "section name"},
+			{name: "<subsectionName>", what: "subsection name", desc: "must be a complete program, tagged {arg:kind: code}; templates with placeholders are refused"},
+		},
+		summary: "Build and run a snippet with the local go toolchain, showing its stdout and stderr",
+	},
+	{names: []string{"lint"}, summary: "Check every section renders without format artefacts or unclosed colours"},
+	{
+		args: []argDoc{
+			{name: "<sectionName>", what: "section name", desc: "is the name of the section"},
+			{name: "<subsectionName> | all", optional: true, desc: "is the name of the subsection, every subsection is shown when omitted or \"all\""},
+		},
+		flags:   []flagDoc{{names: []string{"-p", "--pager"}, desc: "sends the output to {arg:$PAGER}"}},
+		summary: "Get syntax information for a subsection",
+	},
+}
+
+// globalFlags are the flags accepted before or after any command.
+var globalFlags = []flagDoc{
+	{names: []string{"--color"}, value: "<auto | always | never>", desc: "colours output on a terminal, always or never; {arg:NO_COLOR} and {arg:TERM=dumb} turn auto off"},
+	{names: []string{"--theme"}, value: "<name>", desc: "picks the colour theme, such as {arg:light} or {arg:solarized-truecolor}, overriding the config file"},
+	{names: []string{"--format"}, value: "<text | json>", desc: "prints output, and errors and warnings on stderr, as text or JSON"},
+	{names: []string{"--go"}, value: "<version>", desc: "targets a Go release, such as {arg:1.20}: content it lacks is marked, or hidden when reading a whole section; the {arg:go} directive of the nearest go.mod by default"},
+	{names: []string{"--strict"}, desc: "fails instead of going on when a warning is reported, such as for extra arguments"},
+	helpFlag,
+}
+
+// helpFlag asks any command for its usage instead of running it.
+var helpFlag = flagDoc{names: []string{"-h", "--help"}, desc: "shows the usage of a command instead of running it"}
+
+// docSpanPattern matches the {arg:text} and {command:text} spans that
+// emphasise words in doc markup.
+var docSpanPattern = regexp.MustCompile(`\{(arg|command):([^}]*)\}`)
+
+// renderDoc renders doc markup, passing plain text through plain and each
+// emphasised span through emphasise with its role.
+func renderDoc(s string, plain func(string) string, emphasise func(role string, text string) string) string {
+	var out strings.Builder
+	start := 0
+	for _, match := range docSpanPattern.FindAllStringSubmatchIndex(s, -1) {
+		out.WriteString(plain(s[start:match[0]]))
+		out.WriteString(emphasise(s[match[2]:match[3]], s[match[4]:match[5]]))
+		start = match[1]
+	}
+	out.WriteString(plain(s[start:]))
+	return out.String()
+}
+
+// terminalDoc renders doc markup in the active theme.
+func terminalDoc(s string) string {
+	return renderDoc(s, func(text string) string { return text }, func(role string, text string) string {
+		return style(role) + text + Reset
+	})
+}
+
+// name is the name a command is dispatched by, "" for the section lookup.
+func (doc commandDoc) name() string {
+	if len(doc.names) == 0 {
+		return ""
+	}
+	return doc.names[0]
+}
+
+// usage is how a command's arguments and flags are written in help:
+// "<sectionName> [<subsectionName> | all] [-p | --pager]".
+func (doc commandDoc) usage() string {
+	var parts []string
+	for _, arg := range doc.args {
+		if arg.optional {
+			parts = append(parts, "["+arg.name+"]")
+		} else {
+			parts = append(parts, arg.name)
+		}
+	}
+	for _, flag := range doc.flags {
+		if flag.required {
+			parts = append(parts, flag.invocation())
+		} else {
+			parts = append(parts, "["+flag.invocation()+"]")
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+// plainDoc renders doc markup as plain text.
+func plainDoc(s string) string {
+	return renderDoc(s, func(text string) string { return text }, func(role string, text string) string {
+		return text
+	})
+}
+
+// invocation is how a command is written in help: "(help | h)" for a
+// command with aliases, then its usage.
+func (doc commandDoc) invocation() string {
+	name := strings.Join(doc.names, " | ")
+	if len(doc.names) > 1 {
+		name = "(" + name + ")"
+	}
+	return strings.TrimSpace(name + " " + doc.usage())
+}
+
+// options explains the command's arguments and flags, those without a
+// description being left to the usage line.
+func (doc commandDoc) options() []optionDoc {
+	var options []optionDoc
+	for _, arg := range doc.args {
+		if arg.desc != "" {
+			options = append(options, optionDoc{arg.name, arg.desc})
+		}
+	}
+	return append(options, flagOptions(doc.flags)...)
+}
+
+// invocation is how a flag is written in usage: "-r | --regex", or
+// "--out <dir>" for a flag with a value.
+func (flag flagDoc) invocation() string {
+	return strings.TrimSpace(strings.Join(flag.names, " | ") + " " + flag.value)
+}
+
+// key is the spelling a flag's value is stored under.
+func (flag flagDoc) key() string {
+	return flag.names[len(flag.names)-1]
+}
+
+// flagNames returns every spelling of the command's flags.
+func (doc commandDoc) flagNames() []string {
+	var names []string
+	for _, flag := range doc.flags {
+		names = append(names, flag.names...)
+	}
+	return names
+}
+
+func flagOptions(flags []flagDoc) []optionDoc {
+	var options []optionDoc
+	for _, flag := range flags {
+		options = append(options, optionDoc{strings.TrimSpace(strings.Join(flag.names, ", ") + " " + flag.value), flag.desc})
+	}
+	return options
+}
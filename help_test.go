@@ -0,0 +1,55 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+// Invocation
+func TestInvocation(t *testing.T) {
+	tests := []struct {
+		doc  commandDoc
+		want string
+	}{
+		{commandDoc{names: []string{"help", "h"}}, "(help | h)"},
+		{commandDoc{names: []string{"completion"}, args: []argDoc{{name: "<bash | zsh | fish>"}}}, "completion <bash | zsh | fish>"},
+		{commandDoc{args: []argDoc{{name: "<sectionName>"}, {name: "<subsectionName>", optional: true}}}, "<sectionName> [<subsectionName>]"},
+		{commandDoc{names: []string{"search", "s"}, args: []argDoc{{name: "<query>"}}, flags: []flagDoc{{names: []string{"-r", "--regex"}}}}, "(search | s) <query> [-r | --regex]"},
+		{commandDoc{names: []string{"gen-man"}, flags: []flagDoc{{names: []string{"--format"}, value: "<man>"}, {names: []string{"--out"}, value: "<dir>", required: true}}}, "gen-man [--format <man>] --out <dir>"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.doc.invocation(); got != tt.want {
+			t.Errorf("invocation() = %q, want %q", got, tt.want)
+		}
+	}
+}
+
+// Render Doc
+func TestRenderDoc(t *testing.T) {
+	got := renderDoc("run {command:gosyn} with {arg:<query>} {unknown:x}", strings.ToUpper, func(role string, text string) string {
+		return "[" + role + " " + text + "]"
+	})
+	want := "RUN [command gosyn] WITH [arg <query>] {UNKNOWN:X}"
+	if got != want {
+		t.Errorf("renderDoc() = %q, want %q", got, want)
+	}
+}
+
+// List Actions follows commandDocs
+func TestListActionsDocs(t *testing.T) {
+	actions := stripANSI(listActions())
+	for _, doc := range commandDocs {
+		if !strings.Contains(actions, " - "+doc.invocation()+": ") {
+			t.Errorf("listActions() is missing %q", doc.invocation())
+		}
+		for _, option := range doc.options() {
+			if !strings.Contains(actions, "    - "+option.name+" ") {
+				t.Errorf("listActions() is missing option %q of %q", option.name, doc.invocation())
+			}
+		}
+	}
+	if strings.Contains(actions, "{arg:") || strings.Contains(actions, "{command:") {
+		t.Errorf("listActions() left doc markup unrendered:\n%s", actions)
+	}
+}
@@ -0,0 +1,332 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/bbarrington0099/gosyn/reference"
+)
+
+// Output formats accepted by --format.
+const (
+	formatText = "text"
+	formatJSON = "json"
+)
+
+// outputFormat is the format every command writes its output and errors in.
+var outputFormat = formatText
+
+// The JSON documents --format json prints. Field names are part of the
+// schema scripts rely on: add fields rather than renaming or removing them.
+type (
+	sectionsJSON struct {
+		Sections []sectionJSON `json:"sections"`
+	}
+
+	sectionJSON struct {
+		Name        string   `json:"name"`
+		Short       string   `json:"short,omitempty"`
+		Origin      string   `json:"origin,omitempty"`
+		Subsections []string `json:"subsections"`
+	}
+
+	subsectionsJSON struct {
+		Section     string   `json:"section"`
+		Subsections []string `json:"subsections"`
+	}
+
+	snippetJSON struct {
+		Section    string     `json:"section"`
+		Subsection string     `json:"subsection"`
+		Lang       string     `json:"lang"`
+		MinGo      string     `json:"min_go,omitempty"`
+		MaxGo      string     `json:"max_go,omitempty"`
+		Supported  bool       `json:"supported"`
+		Content    string     `json:"content"`
+		Spans      []spanJSON `json:"spans"`
+	}
+
+	spanJSON struct {
+		Role string `json:"role,omitempty"`
+		Text string `json:"text"`
+	}
+
+	snippetsJSON struct {
+		Section  string        `json:"section"`
+		Snippets []snippetJSON `json:"snippets"`
+		Hidden   []string      `json:"hidden,omitempty"`
+	}
+
+	candidatesJSON struct {
+		Query      string          `json:"query"`
+		Candidates []candidateJSON `json:"candidates"`
+	}
+
+	candidateJSON struct {
+		Section    string `json:"section"`
+		Subsection string `json:"subsection"`
+	}
+
+	searchJSON struct {
+		Query   string             `json:"query"`
+		Results []searchResultJSON `json:"results"`
+	}
+
+	searchResultJSON struct {
+		Section    string           `json:"section"`
+		Subsection string           `json:"subsection"`
+		Score      int              `json:"score"`
+		Lines      []searchLineJSON `json:"lines"`
+	}
+
+	searchLineJSON struct {
+		Number int    `json:"number"`
+		Text   string `json:"text"`
+	}
+
+	commandsJSON struct {
+		Commands []string `json:"commands"`
+	}
+
+	commandHelpJSON struct {
+		Command string   `json:"command,omitempty"`
+		Aliases []string `json:"aliases,omitempty"`
+		Usage   string   `json:"usage"`
+		Summary string   `json:"summary"`
+	}
+
+	scriptJSON struct {
+		Shell  string `json:"shell"`
+		Script string `json:"script"`
+	}
+
+	exportJSON struct {
+		Format string   `json:"format"`
+		Dir    string   `json:"dir"`
+		Files  []string `json:"files"`
+	}
+
+	runJSON struct {
+		Section    string `json:"section"`
+		Subsection string `json:"subsection"`
+		Stdout     string `json:"stdout"`
+		Stderr     string `json:"stderr"`
+		ExitCode   int    `json:"exit_code"`
+		TimedOut   bool   `json:"timed_out"`
+	}
+
+	lintJSON struct {
+		Problems []lintProblemJSON `json:"problems"`
+	}
+
+	lintProblemJSON struct {
+		Location string `json:"location"`
+		Problem  string `json:"problem"`
+	}
+
+	errorJSON struct {
+		Error    errorBodyJSON    `json:"error"`
+		Warnings []diagnosticJSON `json:"warnings,omitempty"`
+	}
+
+	errorBodyJSON struct {
+		Code    string `json:"code"`
+		Message string `json:"message"`
+	}
+
+	warningsJSON struct {
+		Warnings []diagnosticJSON `json:"warnings"`
+	}
+
+	diagnosticJSON struct {
+		Code    string `json:"code"`
+		Message string `json:"message"`
+	}
+)
+
+// parseFormatFlag checks the value of --format, returning "text" when it
+// was not given.
+func parseFormatFlag(format string) (string, error) {
+	switch format {
+	case "":
+		return formatText, nil
+	case formatText, formatJSON:
+		return format, nil
+	}
+	return "", withKind(ErrUsage, fmt.Errorf("invalid --format value \"%s\", want text or json", format))
+}
+
+// toJSON encodes v indented, leaving the <, > and & common in snippets
+// unescaped.
+func toJSON(v any) (string, error) {
+	var out strings.Builder
+	encoder := json.NewEncoder(&out)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(v); err != nil {
+		return "", fmt.Errorf("cannot encode JSON: %w", err)
+	}
+	return strings.TrimSuffix(out.String(), "\n"), nil
+}
+
+// formatErrorJSON renders err as {"error": {"code", "message"}}, with the
+// warnings reported before it under "warnings".
+func formatErrorJSON(err error, warnings []diagnostic) string {
+	message := err.Error()
+	output, _ := toJSON(errorJSON{Error: errorBodyJSON{Code: errorCode(err), Message: message}, Warnings: diagnosticsJSON(warnings)})
+	return output
+}
+
+func diagnosticsJSON(warnings []diagnostic) []diagnosticJSON {
+	var docs []diagnosticJSON
+	for _, w := range warnings {
+		docs = append(docs, diagnosticJSON{Code: w.code, Message: w.message})
+	}
+	return docs
+}
+
+func listSectionsJSON(sections []reference.Section) (string, error) {
+	doc := sectionsJSON{Sections: []sectionJSON{}}
+	for _, sec := range sections {
+		doc.Sections = append(doc.Sections, sectionJSON{
+			Name:        sec.Name,
+			Short:       sec.Short,
+			Origin:      sec.Origin,
+			Subsections: subsectionNameList(sec),
+		})
+	}
+	return toJSON(doc)
+}
+
+func listSubsectionsJSON(sections []reference.Section, sectionName string) (string, error) {
+	i, err := resolveSection(sections, sectionName, "")
+	if err != nil {
+		return "", err
+	}
+	return toJSON(subsectionsJSON{Section: sections[i].Name, Subsections: subsectionNameList(sections[i])})
+}
+
+// taxJSON is tax for --format json: a single snippet, or every snippet of
+// the section when showsAll.
+func taxJSON(sections []reference.Section, sectionName string, subsectionName string) (string, error) {
+	i, err := resolveSection(sections, sectionName, "no command found assuming section - ")
+	if err != nil {
+		return "", err
+	}
+	sec := sections[i]
+	if showsAll(sec, subsectionName) {
+		supported, hidden := supportedSubsections(sec)
+		doc := snippetsJSON{Section: sec.Name, Snippets: []snippetJSON{}, Hidden: hidden}
+		for _, sub := range supported {
+			doc.Snippets = append(doc.Snippets, newSnippetJSON(sec, sub))
+		}
+		return toJSON(doc)
+	}
+	j, err := resolveSubsection(sec, subsectionName)
+	if err != nil {
+		return "", err
+	}
+	return toJSON(newSnippetJSON(sec, sec.Subsections[j]))
+}
+
+// lookupJSON is lookup for --format json.
+func lookupJSON(sections []reference.Section, name string) (string, error) {
+	target, ok := resolveLookup(sections, name)
+	switch {
+	case ok && len(target.candidates) > 0:
+		doc := candidatesJSON{Query: name}
+		for _, ref := range target.candidates {
+			sec := sections[ref.Section]
+			doc.Candidates = append(doc.Candidates, candidateJSON{Section: sec.Name, Subsection: sec.Subsections[ref.Subsection].Name})
+		}
+		return toJSON(doc)
+	case ok && target.subsection < 0:
+		return taxJSON(sections, sections[target.section].Name, "")
+	case ok:
+		return taxJSON(sections, sections[target.section].Name, sections[target.section].Subsections[target.subsection].Name)
+	}
+	return "", lookupError(sections, name)
+}
+
+func searchResultsJSON(query string, results []reference.SearchResult) (string, error) {
+	doc := searchJSON{Query: query, Results: []searchResultJSON{}}
+	for _, result := range results {
+		entry := searchResultJSON{Section: result.Section, Subsection: result.Subsection, Score: result.Score, Lines: []searchLineJSON{}}
+		for _, line := range result.Lines {
+			entry.Lines = append(entry.Lines, searchLineJSON{Number: line.Number, Text: line.Text})
+		}
+		doc.Results = append(doc.Results, entry)
+	}
+	return toJSON(doc)
+}
+
+// newSnippetJSON describes sub with its plain content and the markup spans
+// the terminal rendering is built from, highlighted as by reference.Render.
+func newSnippetJSON(sec reference.Section, sub reference.Subsection) snippetJSON {
+	lang := sub.Lang
+	if lang == "" {
+		lang = "go"
+	}
+	spans := reference.Spans(sub)
+	snippet := snippetJSON{
+		Section:    sec.Name,
+		Subsection: sub.Name,
+		Lang:       lang,
+		MinGo:      sub.MinGo,
+		MaxGo:      sub.MaxGo,
+		Supported:  sub.Supports(targetGo),
+		Content:    reference.RenderSpans(spans, reference.Plain),
+		Spans:      []spanJSON{},
+	}
+	for _, span := range spans {
+		snippet.Spans = append(snippet.Spans, spanJSON{Role: span.Role, Text: span.Text})
+	}
+	return snippet
+}
+
+func subsectionNameList(sec reference.Section) []string {
+	names := []string{}
+	for _, sub := range sec.Subsections {
+		names = append(names, sub.Name)
+	}
+	return names
+}
+
+// commandsListJSON lists every command name and alias for help.
+func commandsListJSON() (string, error) {
+	return toJSON(commandsJSON{Commands: actionNames()})
+}
+
+// commandUsageJSON is --help for --format json, the summary without doc
+// markup.
+func commandUsageJSON(doc commandDoc) (string, error) {
+	help := commandHelpJSON{Command: doc.name(), Usage: strings.TrimSpace("gosyn " + doc.invocation()), Summary: plainDoc(doc.summary)}
+	if len(doc.names) > 1 {
+		help.Aliases = doc.names[1:]
+	}
+	return toJSON(help)
+}
+
+func exportResultJSON(format string, dir string, paths []string) (string, error) {
+	return toJSON(exportJSON{Format: format, Dir: dir, Files: paths})
+}
+
+func runResultJSON(result runResult) (string, error) {
+	return toJSON(runJSON{
+		Section:    result.section,
+		Subsection: result.subsection,
+		Stdout:     result.stdout,
+		Stderr:     result.stderr,
+		ExitCode:   result.exitCode,
+		TimedOut:   result.timedOut,
+	})
+}
+
+func completionScriptJSON(shell string) (string, error) {
+	script, err := completionScript(shell)
+	if err != nil {
+		return "", err
+	}
+	return toJSON(scriptJSON{Shell: strings.ToLower(shell), Script: script})
+}
@@ -0,0 +1,167 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/bbarrington0099/gosyn/reference"
+)
+
+func jsonTestSections() []reference.Section {
+	return []reference.Section{
+		{Name: "Variables", Short: "var", Subsections: []reference.Subsection{{Name: "Declaration", Content: "{title:Declaration}:\n\tvar x int"}, {Name: "Types", Content: "int, string"}}},
+		{Name: "BuildRun", Short: "build", Subsections: []reference.Subsection{{Name: "Commands", Lang: "sh", Content: "{kw:go} build"}}},
+		{Name: "Functions", Short: "func", Subsections: []reference.Subsection{{Name: "Declaration", Content: "func f() {}"}}},
+		{Name: "Formatting", Short: "fmt", Subsections: []reference.Subsection{{Name: "Verbs", Content: "%v %d"}}},
+	}
+}
+
+// JSON Output
+func TestRunCommandJSON(t *testing.T) {
+	defer func() { outputFormat = formatText }()
+	outputFormat = formatJSON
+	sections := jsonTestSections()
+
+	tests := []struct {
+		name string
+		args []string
+		into any
+		want any
+	}{
+		{
+			name: "section list",
+			args: []string{"lsec"},
+			into: &sectionsJSON{},
+			want: &sectionsJSON{Sections: []sectionJSON{
+				{Name: "Variables", Short: "var", Subsections: []string{"Declaration", "Types"}},
+				{Name: "BuildRun", Short: "build", Subsections: []string{"Commands"}},
+				{Name: "Functions", Short: "func", Subsections: []string{"Declaration"}},
+				{Name: "Formatting", Short: "fmt", Subsections: []string{"Verbs"}},
+			}},
+		},
+		{
+			name: "subsection list",
+			args: []string{"lsub", "var"},
+			into: &subsectionsJSON{},
+			want: &subsectionsJSON{Section: "Variables", Subsections: []string{"Declaration", "Types"}},
+		},
+		{
+			name: "snippet with hand markup",
+			args: []string{"build", "Commands"},
+			into: &snippetJSON{},
+			want: &snippetJSON{Section: "BuildRun", Subsection: "Commands", Lang: "sh", Supported: true, Content: "go build", Spans: []spanJSON{{Role: "kw", Text: "go"}, {Text: " build"}}},
+		},
+		{
+			name: "lone subsection in several sections",
+			args: []string{"Declaration"},
+			into: &candidatesJSON{},
+			want: &candidatesJSON{Query: "Declaration", Candidates: []candidateJSON{{"Variables", "Declaration"}, {"Functions", "Declaration"}}},
+		},
+		{
+			name: "help",
+			args: []string{"help"},
+			into: &commandsJSON{},
+			want: &commandsJSON{Commands: actionNames()},
+		},
+		{
+			name: "command usage",
+			args: []string{"s", "--help"},
+			into: &commandHelpJSON{},
+			want: &commandHelpJSON{Command: "search", Aliases: []string{"s"}, Usage: "gosyn (search | s) <query> [-r | --regex]", Summary: "Search all snippets for a token"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			output, err := runArgs(sections, tt.args)
+			if err != nil {
+				t.Fatalf("runArgs(%q) error = %v", tt.args, err)
+			}
+			if err := json.Unmarshal([]byte(output), tt.into); err != nil {
+				t.Fatalf("runCommand() output is not JSON: %v\n%s", err, output)
+			}
+			if !reflect.DeepEqual(tt.into, tt.want) {
+				t.Errorf("runCommand() = %+v, want %+v", tt.into, tt.want)
+			}
+		})
+	}
+}
+
+// Highlighted spans add up to the plain content
+func TestSnippetJSONSpans(t *testing.T) {
+	sections := jsonTestSections()
+	snippet := newSnippetJSON(sections[0], sections[0].Subsections[0])
+	var joined strings.Builder
+	roles := map[string]bool{}
+	for _, span := range snippet.Spans {
+		joined.WriteString(span.Text)
+		roles[span.Role] = true
+	}
+	if joined.String() != snippet.Content || snippet.Content != "Declaration:\n\tvar x int" {
+		t.Errorf("spans join to %q, content %q", joined.String(), snippet.Content)
+	}
+	if !roles[roleTitle] || !roles[roleKeyword] || !roles[roleBuiltin] {
+		t.Errorf("spans roles = %v, want title, kw and builtin", roles)
+	}
+	if snippet.Lang != "go" {
+		t.Errorf("lang = %q, want go", snippet.Lang)
+	}
+}
+
+// JSON Errors
+func TestFormatErrorJSON(t *testing.T) {
+	defer func() { outputFormat = formatText }()
+	outputFormat = formatJSON
+
+	tests := []struct {
+		name     string
+		args     []string
+		wantCode string
+		wantMsg  string
+	}{
+		{"unknown section", []string{"lsub", "Nope"}, codeNotFound, `section "Nope" not found`},
+		{"ambiguous section", []string{"lsub", "f"}, codeAmbiguous, `section "f" is ambiguous, could be: Functions, Formatting`},
+		{"missing argument", []string{"lsub"}, codeUsage, "no section name provided for (listSubsections | lsub) <sectionName>"},
+		{"bad regex", []string{"search", "-r", "("}, codeInvalidQuery, "invalid regular expression"},
+		{"unknown subsection", []string{"var", "Nope"}, codeNotFound, `subsection "Nope" not found in section "Variables"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := runArgs(jsonTestSections(), tt.args)
+			if err == nil {
+				t.Fatal("runCommand() error = nil")
+			}
+			var doc errorJSON
+			if err := json.Unmarshal([]byte(formatErrorJSON(err, nil)), &doc); err != nil {
+				t.Fatalf("formatErrorJSON() is not JSON: %v", err)
+			}
+			if doc.Error.Code != tt.wantCode || !strings.HasPrefix(doc.Error.Message, tt.wantMsg) {
+				t.Errorf("formatErrorJSON() = %+v, want code %q and message starting %q", doc.Error, tt.wantCode, tt.wantMsg)
+			}
+			if strings.Contains(doc.Error.Message, "\033[") {
+				t.Errorf("formatErrorJSON() message %q should not be styled", doc.Error.Message)
+			}
+		})
+	}
+
+	if got := errorCode(errors.New("plain")); got != codeInternal {
+		t.Errorf("errorCode() of an uncoded error = %q, want %q", got, codeInternal)
+	}
+}
+
+// Parse Format Flag
+func TestParseFormatFlag(t *testing.T) {
+	if format, err := parseFormatFlag("json"); err != nil || format != formatJSON {
+		t.Errorf("parseFormatFlag(json) = %q, %v", format, err)
+	}
+	if format, err := parseFormatFlag(""); err != nil || format != formatText {
+		t.Errorf("parseFormatFlag() without the flag = %q, %v, want text", format, err)
+	}
+	if _, err := parseFormatFlag("xml"); errorCode(err) != codeUsage {
+		t.Errorf("parseFormatFlag(xml) error = %v, want a usage error", err)
+	}
+}
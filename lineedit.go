@@ -0,0 +1,208 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"unicode"
+)
+
+// errInterrupted is returned by readLine when Ctrl-C abandons the line.
+var errInterrupted = errors.New("interrupted")
+
+// lineEditor reads lines from a terminal already in raw mode, with cursor
+// movement, emacs-style editing keys, history and tab completion.
+type lineEditor struct {
+	reader   *bufio.Reader
+	out      io.Writer
+	history  []string
+	complete func(before string) []string // candidates for the word ending before the cursor
+}
+
+func newLineEditor(in io.Reader, out io.Writer, complete func(string) []string) *lineEditor {
+	return &lineEditor{reader: bufio.NewReader(in), out: out, complete: complete}
+}
+
+// readLine shows prompt and returns the line typed, without its newline.
+// Ctrl-C returns errInterrupted and Ctrl-D on an empty line io.EOF.
+func (e *lineEditor) readLine(prompt string) (string, error) {
+	var line []rune
+	cursor := 0
+	historyAt := len(e.history)
+	draft := ""
+	showHistory := func(to int) {
+		if to < 0 || to > len(e.history) {
+			return
+		}
+		if historyAt == len(e.history) {
+			draft = string(line)
+		}
+		historyAt = to
+		if to == len(e.history) {
+			line = []rune(draft)
+		} else {
+			line = []rune(e.history[to])
+		}
+		cursor = len(line)
+	}
+
+	e.redraw(prompt, line, cursor)
+	for {
+		r, _, err := e.reader.ReadRune()
+		if err != nil {
+			return "", err
+		}
+		switch r {
+		case '\r', '\n':
+			fmt.Fprint(e.out, "\r\n")
+			return string(line), nil
+		case 3: // Ctrl-C
+			fmt.Fprint(e.out, "^C\r\n")
+			return "", errInterrupted
+		case 4: // Ctrl-D
+			if len(line) == 0 {
+				fmt.Fprint(e.out, "\r\n")
+				return "", io.EOF
+			}
+			if cursor < len(line) {
+				line = append(line[:cursor], line[cursor+1:]...)
+			}
+		case 127, 8: // Backspace
+			if cursor > 0 {
+				line = append(line[:cursor-1], line[cursor:]...)
+				cursor--
+			}
+		case 1: // Ctrl-A
+			cursor = 0
+		case 5: // Ctrl-E
+			cursor = len(line)
+		case 2: // Ctrl-B
+			cursor = max(0, cursor-1)
+		case 6: // Ctrl-F
+			cursor = min(len(line), cursor+1)
+		case 11: // Ctrl-K
+			line = line[:cursor]
+		case 21: // Ctrl-U
+			line = line[cursor:]
+			cursor = 0
+		case 23: // Ctrl-W
+			start := cursor
+			for start > 0 && line[start-1] == ' ' {
+				start--
+			}
+			for start > 0 && line[start-1] != ' ' {
+				start--
+			}
+			line = append(line[:start], line[cursor:]...)
+			cursor = start
+		case 16: // Ctrl-P
+			showHistory(historyAt - 1)
+		case 14: // Ctrl-N
+			showHistory(historyAt + 1)
+		case 12: // Ctrl-L
+			fmt.Fprint(e.out, "\033[H\033[2J")
+		case '\t':
+			line, cursor = e.completeWord(line, cursor)
+		case 27: // escape sequence
+			switch readEscape(e.reader) {
+			case "A":
+				showHistory(historyAt - 1)
+			case "B":
+				showHistory(historyAt + 1)
+			case "C":
+				cursor = min(len(line), cursor+1)
+			case "D":
+				cursor = max(0, cursor-1)
+			case "H", "1~", "7~":
+				cursor = 0
+			case "F", "4~", "8~":
+				cursor = len(line)
+			case "3~":
+				if cursor < len(line) {
+					line = append(line[:cursor], line[cursor+1:]...)
+				}
+			}
+		default:
+			if r >= ' ' {
+				line = append(line[:cursor], append([]rune{r}, line[cursor:]...)...)
+				cursor++
+			}
+		}
+		e.redraw(prompt, line, cursor)
+	}
+}
+
+// readEscape reads the rest of an escape sequence after ESC and returns
+// its parameters and final byte, such as "A" for the up arrow or "3~" for
+// delete, or "" for sequences it does not understand.
+func readEscape(reader *bufio.Reader) string {
+	b, err := reader.ReadByte()
+	if err != nil || (b != '[' && b != 'O') {
+		return ""
+	}
+	var seq strings.Builder
+	for {
+		b, err := reader.ReadByte()
+		if err != nil {
+			return ""
+		}
+		seq.WriteByte(b)
+		if b >= 0x40 && b <= 0x7e {
+			return seq.String()
+		}
+	}
+}
+
+func (e *lineEditor) redraw(prompt string, line []rune, cursor int) {
+	fmt.Fprintf(e.out, "\r%s%s\033[K", prompt, string(line))
+	if back := len(line) - cursor; back > 0 {
+		fmt.Fprintf(e.out, "\033[%dD", back)
+	}
+}
+
+// completeWord completes the word before the cursor: a single candidate
+// replaces it, several are narrowed to their common prefix, and when that
+// adds nothing they are listed below the prompt.
+func (e *lineEditor) completeWord(line []rune, cursor int) ([]rune, int) {
+	if e.complete == nil {
+		return line, cursor
+	}
+	before := string(line[:cursor])
+	start := strings.LastIndexByte(before, ' ') + 1
+	word := before[start:]
+	candidates := e.complete(before)
+
+	var replacement string
+	switch {
+	case len(candidates) == 0:
+		fmt.Fprint(e.out, "\a")
+		return line, cursor
+	case len(candidates) == 1:
+		replacement = candidates[0] + " "
+	default:
+		replacement = commonPrefix(candidates)
+		if len(replacement) <= len(word) {
+			fmt.Fprintf(e.out, "\r\n%s\r\n", strings.Join(candidates, "  "))
+			return line, cursor
+		}
+	}
+	completed := before[:start] + replacement
+	return []rune(completed + string(line[cursor:])), len([]rune(completed))
+}
+
+// commonPrefix returns the longest prefix, ignoring case, shared by every
+// candidate, spelled as in the first.
+func commonPrefix(candidates []string) string {
+	prefix := []rune(candidates[0])
+	for _, candidate := range candidates[1:] {
+		other := []rune(candidate)
+		n := 0
+		for n < len(prefix) && n < len(other) && unicode.ToLower(prefix[n]) == unicode.ToLower(other[n]) {
+			n++
+		}
+		prefix = prefix[:n]
+	}
+	return string(prefix)
+}
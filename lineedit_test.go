@@ -0,0 +1,55 @@
+package main
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+// Read Line
+func TestReadLine(t *testing.T) {
+	tests := []struct {
+		name    string
+		keys    string
+		history []string
+		want    string
+		wantErr error
+	}{
+		{"plain", "lsec\r", nil, "lsec", nil},
+		{"backspace", "lsex\x7fc\r", nil, "lsec", nil},
+		{"insert after left arrow", "lec\x1b[D\x1b[Ds\r", nil, "lsec", nil},
+		{"home and delete", "xlsec\x1b[H\x1b[3~\r", nil, "lsec", nil},
+		{"kill word", "chan Sel\x17Select\r", nil, "chan Select", nil},
+		{"kill to start", "oops\x15lsec\r", nil, "lsec", nil},
+		{"history up", "\x1b[A\x1b[A\r", []string{"lsec", "chan"}, "lsec", nil},
+		{"history back down", "ls\x1b[A\x1b[B\r", []string{"chan"}, "ls", nil},
+		{"tab completes", "Sel\t\r", nil, "Select ", nil},
+		{"ctrl-c", "abc\x03", nil, "", errInterrupted},
+		{"ctrl-d", "\x04", nil, "", io.EOF},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out strings.Builder
+			e := newLineEditor(strings.NewReader(tt.keys), &out, func(before string) []string {
+				return filterCandidates([]string{"Select", "search"}, before[strings.LastIndexByte(before, ' ')+1:])
+			})
+			e.history = tt.history
+			got, err := e.readLine("> ")
+			if !errors.Is(err, tt.wantErr) || got != tt.want {
+				t.Errorf("readLine(%q) = %q, %v, want %q, %v", tt.keys, got, err, tt.want, tt.wantErr)
+			}
+		})
+	}
+}
+
+// Common Prefix
+func TestCommonPrefix(t *testing.T) {
+	if got := commonPrefix([]string{"Select", "search"}); got != "Se" {
+		t.Errorf("commonPrefix() = %q, want %q", got, "Se")
+	}
+	if got := commonPrefix([]string{"Buffered", "Basic"}); got != "B" {
+		t.Errorf("commonPrefix() = %q, want %q", got, "B")
+	}
+}
@@ -0,0 +1,118 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/bbarrington0099/gosyn/reference"
+)
+
+// lintProblem is something wrong with rendered output, found by lintOutput.
+type lintProblem struct {
+	location string // "Section/Subsection", "Section" or the command at fault
+	problem  string
+}
+
+// escapePattern matches any CSI escape sequence, well-formed SGR or not.
+var escapePattern = regexp.MustCompile("\033\\[[0-9;]*[a-zA-Z]?")
+
+// lintSections renders every command's output for sections, in colour
+// whatever the terminal, and returns the problems lintOutput finds in it.
+func lintSections(sections []reference.Section) []lintProblem {
+	defer setColor(colorOn)
+	setColor(true)
+
+	var problems []lintProblem
+	check := func(location string, output string) {
+		for _, problem := range lintOutput(output) {
+			problems = append(problems, lintProblem{location: location, problem: problem})
+		}
+	}
+
+	check("help", listActions())
+	check("listSections", listSections(sections))
+	for _, sec := range sections {
+		subsections, err := listSubsections(sections, sec.Name)
+		if err != nil {
+			problems = append(problems, lintProblem{location: sec.Name, problem: err.Error()})
+			continue
+		}
+		check(sec.Name, subsections)
+		before := len(problems)
+		for _, sub := range sec.Subsections {
+			output, err := tax(sections, sec.Name, sub.Name)
+			if err != nil {
+				problems = append(problems, lintProblem{location: sec.Name + "/" + sub.Name, problem: err.Error()})
+				continue
+			}
+			check(sec.Name+"/"+sub.Name, output)
+		}
+		// A subsection's problems would show again in the whole section.
+		if len(problems) == before {
+			check(sec.Name, taxSection(sec))
+		}
+	}
+	return problems
+}
+
+// lintOutput returns what is wrong with rendered output: "%!" artefacts
+// from a format string and its arguments disagreeing, escape sequences
+// that are not SGR, and styles left open, that is not followed by a Reset
+// before the output ends.
+func lintOutput(output string) []string {
+	var problems []string
+	if i := strings.Index(output, "%!"); i >= 0 {
+		artefact := output[i:]
+		if end := strings.IndexAny(artefact, ")\n"); end >= 0 {
+			artefact = artefact[:end+1]
+		}
+		problems = append(problems, fmt.Sprintf("format artefact %q", strings.TrimSpace(stripANSI(artefact))))
+	}
+
+	open := false
+	for _, sequence := range escapePattern.FindAllString(output, -1) {
+		switch {
+		case !strings.HasSuffix(sequence, "m"):
+			problems = append(problems, fmt.Sprintf("malformed escape sequence %q", sequence))
+		case sequence == Reset || sequence == "\033[m":
+			open = false
+		default:
+			open = true
+		}
+	}
+	if open {
+		problems = append(problems, "style not closed by a trailing Reset")
+	}
+	return problems
+}
+
+// lintReport lists problems in an error, or says there are none. With
+// --format json the problems are also returned as a document, which main
+// prints on stdout before the error.
+func lintReport(problems []lintProblem) (string, error) {
+	var err error
+	if len(problems) > 0 {
+		report := fmt.Sprintf("%d problem(s) found:", len(problems))
+		for _, p := range problems {
+			report += fmt.Sprintf("\n - %s: %s", p.location, p.problem)
+		}
+		err = withKind(ErrLint, errors.New(report))
+	}
+	if outputFormat == formatJSON {
+		doc := lintJSON{Problems: []lintProblemJSON{}}
+		for _, p := range problems {
+			doc.Problems = append(doc.Problems, lintProblemJSON{Location: p.location, Problem: p.problem})
+		}
+		output, jsonErr := toJSON(doc)
+		if jsonErr != nil {
+			return "", jsonErr
+		}
+		return output, err
+	}
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%sNo problems found%s", style(roleHeading), Reset), nil
+}
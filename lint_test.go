@@ -0,0 +1,102 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/bbarrington0099/gosyn/reference"
+)
+
+// Built-in content lints clean in every theme
+func TestLintSections(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	defer setTheme(defaultTheme)
+	sections := initializeSections()
+
+	for _, name := range themeNames() {
+		if err := setTheme(name); err != nil {
+			t.Fatal(err)
+		}
+		for _, p := range lintSections(sections) {
+			t.Errorf("theme %s: %s: %s", name, p.location, p.problem)
+		}
+	}
+}
+
+// Lint Output
+func TestLintOutput(t *testing.T) {
+	tests := []struct {
+		name   string
+		output string
+		want   []string
+	}{
+		{"clean", fmt.Sprintf("%shead%s text", style(roleHeading), Reset), nil},
+		{"missing argument", style(roleLiteral) + "Variables" + Reset + " %!s(MISSING)", []string{`format artefact "%!s(MISSING)"`}},
+		{"extra argument", style(roleLiteral) + Reset + "\n%!(EXTRA string=x)", []string{`format artefact "%!(EXTRA string=x)"`}},
+		{"no trailing reset", style(roleLiteral) + "Variables", []string{"style not closed by a trailing Reset"}},
+		{"reset before style", Reset + style(roleLiteral) + "x", []string{"style not closed by a trailing Reset"}},
+		{"malformed escape", "\033[31", []string{`malformed escape sequence "\x1b[31"`}},
+		{"short reset", style(roleLiteral) + "x\033[m", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := lintOutput(tt.output)
+			if strings.Join(got, "|") != strings.Join(tt.want, "|") {
+				t.Errorf("lintOutput(%q) = %q, want %q", tt.output, got, tt.want)
+			}
+		})
+	}
+}
+
+// Lint names the section and subsection at fault
+func TestLintSectionsLocation(t *testing.T) {
+	sections := []reference.Section{
+		{Name: "Variables", Short: "var", Subsections: []reference.Subsection{
+			{Name: "Good", Lang: "text", Content: "fine"},
+			{Name: "Bad", Lang: "text", Content: "printf(\"%!d(string=x)\")"},
+		}},
+	}
+	problems := lintSections(sections)
+	found := false
+	for _, p := range problems {
+		if p.location == "Variables/Bad" && strings.HasPrefix(p.problem, "format artefact") {
+			found = true
+		}
+		if p.location == "Variables/Good" {
+			t.Errorf("lintSections() blamed Variables/Good: %s", p.problem)
+		}
+	}
+	if !found {
+		t.Errorf("lintSections() = %+v, want a format artefact in Variables/Bad", problems)
+	}
+
+	_, err := lintReport(problems)
+	if errorCode(err) != codeLint || !strings.Contains(err.Error(), "Variables/Bad: format artefact") {
+		t.Errorf("lintReport() error = %v, want a lint error naming Variables/Bad", err)
+	}
+}
+
+// Lint Report in JSON
+func TestLintReportJSON(t *testing.T) {
+	defer func() { outputFormat = formatText }()
+	outputFormat = formatJSON
+
+	output, err := lintReport([]lintProblem{{location: "Variables/Bad", problem: "style not closed by a trailing Reset"}})
+	if errorCode(err) != codeLint {
+		t.Errorf("lintReport() error = %v, want a lint error", err)
+	}
+	var doc lintJSON
+	if err := json.Unmarshal([]byte(output), &doc); err != nil {
+		t.Fatalf("lintReport() output is not JSON: %v", err)
+	}
+	if len(doc.Problems) != 1 || doc.Problems[0].Location != "Variables/Bad" {
+		t.Errorf("lintReport() = %+v", doc)
+	}
+
+	if output, err := lintReport(nil); err != nil || output != "{\n  \"problems\": []\n}" {
+		t.Errorf("lintReport(nil) = %q, %v", output, err)
+	}
+}
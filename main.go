@@ -1,151 +1,170 @@
 package main
 
 import (
+	"errors"
 	"fmt"
-	"log"
 	"os"
+	"slices"
 	"strings"
-)
-
-const (
-    Reset  = "\033[0m"
-    Green  = "\033[32m"
-    Yellow = "\033[33m"
-	Cyan   = "\033[36m"
-    
-    BoldRed    = "\033[1;31m"
-    BoldGreen  = "\033[1;32m"
-    BoldYellow = "\033[1;33m"
-	BoldPurple = "\033[1;35m"
-	BoldCyan   = "\033[1;36m"
 
-	BoldUnderline = "\033[1;4m"
-
-	Italic = "\033[3m"
-	BoldItalic = "\033[1;3m"
+	"github.com/bbarrington0099/gosyn/reference"
 )
 
-type command struct {
-	action string
-	args []string
-}
-
-type section struct {
-	name string
-	short string
-	subsections []subsection
-}
-
-type subsection struct {
-	name string
-	content string
-}
+// Reset ends the style started by style(role). It is a variable so that
+// setColor can turn colour off in one place; see color.go.
+var Reset = ansiReset
 
 var (
 	initializeSectionsFn = initializeSections
 )
 
-func parseCommand() (command, error) {
-	var err error = nil
-	var cmd command
-	if len(os.Args) < 2 {
-		err = fmt.Errorf("%sERROR%s parseCommand(): no command provided. use \"%sgosyn help%s\"", 
-		BoldRed, Reset, // ERROR
-		BoldItalic, Reset, // gosyn help
-	)
-		return cmd, err
-	}
-	cmd = command{
-		action: os.Args[1],
-		args:   []string{""},
-	}
-
-	if len(os.Args) > 2 {
-		cmd.args = os.Args[2:]
-		for i := 0; i < len(cmd.args); i++ {
-			if cmd.args[i] == "" {
-				cmd.args = append(cmd.args[:i], cmd.args[i+1:]...)
-				i--
-			}
-		}
-	}
-
-	return cmd, err
-}
-
-func executeCommand() (string, error) {
+// runCommand runs a parsed command against sections, for executeCommand
+// and for each line of the REPL.
+func runCommand(sections []reference.Section, cmd command) (string, error) {
 	var err error = nil
-	cmd, parseErr := parseCommand()
-	if parseErr != nil {
-		return "", parseErr	
+	switch {
+	case cmd.doc == nil && cmd.help():
+		return listActions(), err
+	case cmd.doc == nil:
+		err = withKind(ErrUsage, errors.New("no command provided. use \"gosyn help\""))
+		return "", err
+	case cmd.help() && outputFormat == formatJSON:
+		return commandUsageJSON(*cmd.doc)
+	case cmd.help():
+		return commandHelp(*cmd.doc), err
 	}
-	sections := initializeSectionsFn()
-
-	switch strings.ToLower(cmd.action) {
-	case "h":
-		fallthrough
+	switch cmd.doc.name() {
 	case "help":
-		if cmd.args[0] != "" {
-			fmt.Printf("%sWARNING%s executeCommand(): too many arguments provided for help command, following Args ignored:\n%v\n", BoldPurple, Reset, cmd.args)
+		if outputFormat == formatJSON {
+			return commandsListJSON()
 		}
 		return listActions(), err
-	case "lsec":
-		fallthrough
-	case "listsections":
-		if cmd.args[0] != "" {
-			fmt.Printf("%sWARNING%s executeCommand(): too many arguments provided for listSections command, following Args ignored:\n%v\n", BoldPurple, Reset, cmd.args)
+	case "listSections":
+		if outputFormat == formatJSON {
+			return listSectionsJSON(sections)
 		}
 		return listSections(sections), err
-	case "lsub":
-		fallthrough
-	case "listsubsections":
-		if cmd.args[0] == "" {
-			err = fmt.Errorf("%sERROR%s executeCommand(): no section name provided for listSubsections <sectionName>", BoldRed, Reset)
+	case "listSubsections":
+		if outputFormat == formatJSON {
+			return listSubsectionsJSON(sections, cmd.args[0])
+		}
+		return listSubsections(sections, cmd.args[0])
+	case "search":
+		query := strings.TrimSpace(cmd.args[0])
+		if query == "" {
+			err = withKind(ErrUsage, fmt.Errorf("no query provided for %s", cmd.doc.invocation()))
 			return "", err
 		}
+		results, err := searchSections(sections, query, cmd.flags["--regex"] != "")
+		if err != nil {
+			return "", err
+		}
+		if outputFormat == formatJSON {
+			return searchResultsJSON(query, results)
+		}
+		return formatSearchResults(query, results), nil
+	case "completion":
+		if outputFormat == formatJSON {
+			return completionScriptJSON(cmd.args[0])
+		}
+		return completionScript(cmd.args[0])
+	case completeCommand:
+		return strings.Join(complete(sections, cmd.args), "\n"), err
+	case "repl", "browse":
+		err = withKind(ErrUsage, fmt.Errorf("%s can only be started from the command line", cmd.doc.name()))
+		return "", err
+	case "export":
+		format, err := parseExportFormat(cmd.flags["--format"])
+		if err != nil {
+			return "", err
+		}
+		dir := cmd.flags["--out"]
+		paths, err := exportSections(sections, format, dir)
+		if err != nil {
+			return "", err
+		}
+		if outputFormat == formatJSON {
+			return exportResultJSON(format, dir, paths)
+		}
+		return exportSummary(sections, format, dir, paths), nil
+	case "run":
+		result, err := runSnippet(sections, cmd.args[0], cmd.args[1])
+		if err != nil {
+			return "", err
+		}
+		if outputFormat == formatJSON {
+			return runResultJSON(result)
+		}
+		return formatRunResult(result), nil
+	case "lint":
+		return lintReport(lintSections(sections))
+	case "gen-man":
+		dir := cmd.flags["--out"]
+		paths, err := writeManPages(sections, dir)
+		if err != nil {
+			return "", err
+		}
+		if outputFormat == formatJSON {
+			return exportResultJSON("man", dir, paths)
+		}
+		return manSummary(paths), nil
+	default:
+		sectionName, subsectionName := cmd.args[0], ""
 		if len(cmd.args) > 1 {
-			fmt.Printf("%sWARNING%s executeCommand(): too many arguments provided for listSubsections command, following Args ignored:\n%v\n", BoldPurple, Reset, cmd.args[1:])
+			subsectionName = cmd.args[1]
 		}
-		return listSubsections(sections, cmd.args[0])
-	default:		
-		return tax(sections, cmd.action, cmd.args[0])
+		var output string
+		switch {
+		case subsectionName == "" && outputFormat == formatJSON:
+			output, err = lookupJSON(sections, sectionName)
+		case subsectionName == "":
+			output, err = lookup(sections, sectionName)
+		case outputFormat == formatJSON:
+			output, err = taxJSON(sections, sectionName, subsectionName)
+		default:
+			output, err = tax(sections, sectionName, subsectionName)
+		}
+		if err != nil || cmd.flags["--pager"] == "" {
+			return output, err
+		}
+		return page(output)
 	}
 }
 
 func listActions() string {
-	return fmt.Sprintf(("%sAvailable commands%s:\n" +
-		" - %s(help | h)%s: List all available commands\n" +
-		" - %s(listSections | lsec)%s: List all sections\n" +
-		" - %s(listSubsections | lsub) <sectionName>%s: List all subsections in a section\n" +
-		"    - %s<sectionName>%s is the name of the section to list subsections for\n" +
-		" - %s<sectionName> <subsectionName>%s: Get syntax information for a subsection\n" +
-		"    - %s<sectionName>%s is the name of the section\n" +
-		"    - %s<subsectionName>%s is the name of the subsection\n"),
-		BoldUnderline, Reset, // Available commands
-		BoldYellow, Reset, // help
-		BoldCyan, Reset, // listSections
-		BoldCyan, Reset, // listSubsections
-		Italic, Reset, // > sectionName
-		BoldGreen, Reset, // tax
-		Italic, Reset, // > sectionName
-		Italic, Reset, // > subsectionName
-	)
+	output := fmt.Sprintf("%sAvailable commands%s:\n", style(roleHeading), Reset)
+	for _, doc := range commandDocs {
+		output += fmt.Sprintf(" - %s%s%s: %s\n", style(roleCommand), doc.invocation(), Reset, terminalDoc(doc.summary))
+		for _, option := range doc.options() {
+			output += fmt.Sprintf("    - %s%s%s %s\n", style(roleArg), option.name, Reset, terminalDoc(option.desc))
+		}
+	}
+	output += fmt.Sprintf("%sGlobal flags%s, before or after any command:\n", style(roleHeading), Reset)
+	for _, option := range flagOptions(globalFlags) {
+		output += fmt.Sprintf(" - %s%s%s %s\n", style(roleArg), option.name, Reset, terminalDoc(option.desc))
+	}
+	return output
 }
 
-func listSections(sections []section) (string) {
-	output := fmt.Sprintf("%sSections%s:\n", BoldItalic, Reset)
+func listSections(sections []reference.Section) (string) {
+	output := fmt.Sprintf("%sSections%s:\n", style(roleHeading), Reset)
 	for _, sec := range sections {
-		output += fmt.Sprintf(" - %s%s%s %s%s%s\n", 
-			BoldUnderline, sec.name, Reset, // section name
-			Italic, sec.short, Reset, // short name
+		output += fmt.Sprintf(" - %s%s%s %s%s%s%s\n", 
+			style(roleSection), sec.Name, Reset, // section name
+			style(roleMeta), sec.Short, Reset, // short name
+			originMarker(sec),
 		)
 		listed := 0
-		for _, sub := range sec.subsections {
+		for _, sub := range sec.Subsections {
 			if listed == 3 {
 				output += "\n"
 				listed = 0
 			}
-			output += fmt.Sprintf("   - %s%s%s", Yellow, sub.name, Reset)
+			marker := ""
+			if sub.Origin != "" && sec.Origin == "" {
+				marker = "*"
+			}
+			output += fmt.Sprintf("   - %s%s%s%s", style(roleSubsection), sub.Name, Reset, marker)
 			listed++
 		}
 		if listed > 0 {
@@ -155,1232 +174,289 @@ func listSections(sections []section) (string) {
 	return output
 }
 
-func listSubsections(sections []section, sectionName string) (string, error) {
-	var err error = nil
-	for _, sec := range sections {
-		if strings.EqualFold(sec.name, sectionName) || strings.EqualFold(sec.short, sectionName) {
-			output := fmt.Sprintf("%sSubsections%s in %s%s%s:\n", 
-			BoldYellow, Reset, // Subsections
-			BoldGreen, sec.name, Reset) // sectionName
-			for _, sub := range sec.subsections {
-				output += fmt.Sprintf("   - %s\n", sub.name)
-			}
-			return output, err
+// originMarker describes where a user-defined section, or the user
+// subsections extending a built-in one (marked with "*"), were loaded from.
+func originMarker(sec reference.Section) string {
+	if sec.Origin != "" {
+		return fmt.Sprintf(" %s(user: %s)%s", style(roleMeta), sec.Origin, Reset)
+	}
+	var origins []string
+	for _, sub := range sec.Subsections {
+		if sub.Origin != "" && !slices.Contains(origins, sub.Origin) {
+			origins = append(origins, sub.Origin)
 		}
 	}
-	err = fmt.Errorf("%sERROR%s listSubsections(): section \"%s\" not found", BoldRed, Reset, sectionName)
-	return "", err
+	if len(origins) == 0 {
+		return ""
+	}
+	return fmt.Sprintf(" %s(*extended by: %s)%s", style(roleMeta), strings.Join(origins, ", "), Reset)
 }
 
-func tax(sections []section, sectionName string, subsectionName string) (string, error) {
-	var err error = nil
-	for _, sec := range sections {
-		if strings.EqualFold(sec.name, sectionName) || strings.EqualFold(sec.short, sectionName) {
-			if subsectionName == "" {
-				err = fmt.Errorf("%sERROR%s executeCommand(): no subsection name provided for tax <sectionName> <subsectionName> in args \"%v\"", BoldRed, Reset, os.Args[1:])
-				return "", err
-			}
-			for _, sub := range sec.subsections {
-				if strings.EqualFold(sub.name, subsectionName) {
-					return fmt.Sprintf("%sSyntax information%s for %s%s%s in %s%s%s:\n%s\n", 
-					BoldPurple, Reset, // Syntax information
-					Yellow, sub.name, Reset, // subsectionName
-					Green, sec.name, Reset, // sectionName
-					sub.content), err
-				}
-			}
-			err = fmt.Errorf("%sERROR%s tax(): subsection \"%s\" not found in section \"%s\"", BoldRed, Reset, subsectionName, sectionName)
-			return "", err
-		}
+func listSubsections(sections []reference.Section, sectionName string) (string, error) {
+	i, err := resolveSection(sections, sectionName, "")
+	if err != nil {
+		return "", err
 	}
-	err = fmt.Errorf("%sERROR%s tax(): no command found assuming section - section \"%s\" not found", BoldRed, Reset, sectionName)
-	return "", err
+	sec := sections[i]
+	output := fmt.Sprintf("%sSubsections%s in %s%s%s:\n", 
+	style(roleHeading), Reset, // Subsections
+	style(roleSection), sec.Name, Reset) // sectionName
+	for _, sub := range sec.Subsections {
+		output += fmt.Sprintf("   - %s%s\n", sub.Name, goNote(sub))
+	}
+	return output, err
+}
+
+// resolveSection resolves sectionName with reference.LookupSection,
+// wording its ambiguous or not-found error. context is put before
+// "section ... not found" to explain how the name was read.
+func resolveSection(sections []reference.Section, sectionName string, context string) (int, error) {
+	i, err := reference.LookupSection(sections, sectionName)
+	var ambiguous *reference.AmbiguousError
+	var notFound *reference.NotFoundError
+	switch {
+	case errors.As(err, &ambiguous):
+		err = withKind(ErrAmbiguous, fmt.Errorf("section \"%s\" is ambiguous, could be: %s", sectionName, strings.Join(ambiguous.Candidates, ", ")))
+	case errors.As(err, &notFound):
+		err = withKind(ErrSectionNotFound, fmt.Errorf("%ssection \"%s\" not found%s", context, sectionName, didYouMean(notFound.Suggestions)))
+	}
+	return i, err
+}
+
+// resolveSubsection resolves subsectionName within sec with
+// reference.LookupSubsection, wording its ambiguous or not-found error.
+func resolveSubsection(sec reference.Section, subsectionName string) (int, error) {
+	j, err := reference.LookupSubsection(sec, subsectionName)
+	var ambiguous *reference.AmbiguousError
+	var notFound *reference.NotFoundError
+	switch {
+	case errors.As(err, &ambiguous):
+		err = withKind(ErrAmbiguous, fmt.Errorf("subsection \"%s\" is ambiguous in section \"%s\", could be: %s", subsectionName, sec.Name, strings.Join(ambiguous.Candidates, ", ")))
+	case errors.As(err, &notFound):
+		err = withKind(ErrSubsectionNotFound, fmt.Errorf("subsection \"%s\" not found in section \"%s\"%s", subsectionName, sec.Name, didYouMean(notFound.Suggestions)))
+	}
+	return j, err
+}
+
+// showsAll reports whether subsectionName asks tax for every subsection of
+// sec: it is empty, or "all" when no subsection is actually named All.
+func showsAll(sec reference.Section, subsectionName string) bool {
+	if subsectionName == "" {
+		return true
+	}
+	return strings.EqualFold(subsectionName, "all") && !slices.ContainsFunc(sec.Subsections, func(sub reference.Subsection) bool {
+		return strings.EqualFold(sub.Name, "all")
+	})
+}
+
+func tax(sections []reference.Section, sectionName string, subsectionName string) (string, error) {
+	i, err := resolveSection(sections, sectionName, "no command found assuming section - ")
+	if err != nil {
+		return "", err
+	}
+	sec := sections[i]
+	if showsAll(sec, subsectionName) {
+		return taxSection(sec), err
+	}
+	j, err := resolveSubsection(sec, subsectionName)
+	if err != nil {
+		return "", err
+	}
+	sub := sec.Subsections[j]
+	return fmt.Sprintf("%sSyntax information%s for %s%s%s in %s%s%s%s:\n%s\n", 
+	style(roleHeading), Reset, // Syntax information
+	style(roleSubsection), sub.Name, Reset, // subsectionName
+	style(roleSection), sec.Name, Reset, // sectionName
+	goNote(sub),
+	reference.Render(sub, terminalRenderer)), err
+}
+
+// taxSection renders every subsection of sec in order, each under its own
+// heading. Subsections targetGo does not support are only named at the end.
+func taxSection(sec reference.Section) string {
+	output := fmt.Sprintf("%sSyntax information%s for %sall subsections%s in %s%s%s:\n", 
+	style(roleHeading), Reset, // Syntax information
+	style(roleSubsection), Reset, // all subsections
+	style(roleSection), sec.Name, Reset) // sectionName
+	supported, hidden := supportedSubsections(sec)
+	for _, sub := range supported {
+		output += fmt.Sprintf("\n%s%s%s%s\n%s\n", 
+		style(roleHeading), sub.Name, Reset, // subsectionName
+		goNote(sub),
+		reference.Render(sub, terminalRenderer))
+	}
+	if len(hidden) > 0 {
+		output += fmt.Sprintf("\n%sHidden%s for Go %s, see %s--go%s: %s\n", 
+		style(roleWarning), Reset, // Hidden
+		targetGo,
+		style(roleArg), Reset, // --go
+		strings.Join(styleNames(hidden, roleSubsection), ", "))
+	}
+	return output
+}
+
+// lookup resolves a lone argument that may name either a section or a
+// subsection of any section, see resolveLookup. A unique subsection match
+// is printed as by tax; several are listed as Section/Subsection paths.
+func lookup(sections []reference.Section, name string) (string, error) {
+	target, ok := resolveLookup(sections, name)
+	switch {
+	case ok && len(target.candidates) > 0:
+		return listSubsectionCandidates(sections, name, target.candidates), nil
+	case ok && target.subsection < 0:
+		return tax(sections, sections[target.section].Name, "")
+	case ok:
+		return tax(sections, sections[target.section].Name, sections[target.section].Subsections[target.subsection].Name)
+	}
+	return "", lookupError(sections, name)
+}
+
+// lookupError explains why resolveLookup could not resolve name: the
+// section prefix is ambiguous, or nothing close to it exists.
+func lookupError(sections []reference.Section, name string) error {
+	if _, err := resolveSection(sections, name, ""); errors.Is(err, ErrAmbiguous) {
+		return err
+	}
+	suggestions := append(reference.SuggestSections(sections, name), reference.SuggestAnySubsections(sections, name)...)
+	if len(suggestions) > 3 {
+		suggestions = suggestions[:3]
+	}
+	return withKind(ErrSectionNotFound, fmt.Errorf("no command found - section or subsection \"%s\" not found%s", name, didYouMean(suggestions)))
+}
+
+// lookupTarget is what a lone name resolves to: a section when subsection
+// is -1, one of its subsections, or, when candidates is set, several
+// subsections sharing the name.
+type lookupTarget struct {
+	section    int
+	subsection int
+	candidates []reference.Ref
+}
+
+// resolveLookup resolves a lone name to a section or subsection. Exact
+// names are tried before partial ones, and a section name before a
+// subsection name, so "Functions" stays the section even though Pointers
+// has a Functions subsection. It reports false when nothing matches or the
+// name is an ambiguous section prefix.
+func resolveLookup(sections []reference.Section, name string) (lookupTarget, bool) {
+	if i := reference.Find(sections, name); i >= 0 {
+		return lookupTarget{section: i, subsection: -1}, true
+	}
+	if ref, candidates, ok := reference.MatchAnySubsection(sections, name, true); ok {
+		return lookupTarget{section: ref.Section, subsection: ref.Subsection}, true
+	} else if len(candidates) > 0 {
+		return lookupTarget{section: -1, subsection: -1, candidates: candidates}, true
+	}
+	i, candidates := reference.MatchSection(sections, name)
+	if i >= 0 {
+		return lookupTarget{section: i, subsection: -1}, true
+	}
+	if len(candidates) > 0 {
+		return lookupTarget{section: -1, subsection: -1}, false
+	}
+	if ref, candidates, ok := reference.MatchAnySubsection(sections, name, false); ok {
+		return lookupTarget{section: ref.Section, subsection: ref.Subsection}, true
+	} else if len(candidates) > 0 {
+		return lookupTarget{section: -1, subsection: -1, candidates: candidates}, true
+	}
+	return lookupTarget{section: -1, subsection: -1}, false
+}
+
+func listSubsectionCandidates(sections []reference.Section, name string, candidates []reference.Ref) string {
+	output := fmt.Sprintf("%sSubsections%s matching %s%s%s:\n", 
+	style(roleHeading), Reset, // Subsections
+	style(roleArg), name, Reset) // name
+	for _, ref := range candidates {
+		sec := sections[ref.Section]
+		output += fmt.Sprintf("   - %s%s%s/%s%s%s\n", 
+		style(roleSection), sec.Name, Reset, // sectionName
+		style(roleSubsection), sec.Subsections[ref.Subsection].Name, Reset) // subsectionName
+	}
+	return output
 }
 
 func main() {
-	output, commandError := executeCommand()
+	cmd, parseError := parseCommand(os.Args[1:])
+	mode, colorError := parseColorFlag(cmd.globals["--color"])
+	if colorError != nil {
+		fatal(colorAuto, colorError)
+	}
+	format, formatError := parseFormatFlag(cmd.globals["--format"])
+	if formatError != nil {
+		fatal(mode, formatError)
+	}
+	outputFormat = format
+	goVersion, goError := parseGoFlag(cmd.globals["--go"])
+	if goError != nil {
+		fatal(mode, goError)
+	}
+	targetGo = goVersion
+	if parseError != nil {
+		fatal(mode, parseError)
+	}
+	if cmd.action == completeCommand {
+		setColor(false)
+	} else {
+		setColor(colorEnabled(mode, os.Stdout) && outputFormat != formatJSON)
+		if themeError := setTheme(configuredTheme(cmd.globals["--theme"])); themeError != nil {
+			fatal(mode, themeError)
+		}
+	}
+	sections := initializeSectionsFn()
+	strict := cmd.globals["--strict"] != ""
+	if strict && len(diagnostics) > 0 {
+		fatal(mode, strictError(len(diagnostics)))
+	}
+	if !cmd.help() && cmd.doc != nil && cmd.doc.name() == "browse" {
+		writeWarnings(mode)
+		if browseError := runBrowser(sections, os.Stdin, os.Stdout); browseError != nil {
+			fatal(mode, browseError)
+		}
+		writeWarnings(mode)
+		return
+	}
+	bareOnTerminal := cmd.doc == nil && !cmd.help() && isTerminal(os.Stdin) && isTerminal(os.Stdout)
+	if bareOnTerminal || !cmd.help() && cmd.doc != nil && cmd.doc.name() == "repl" {
+		writeWarnings(mode)
+		if replError := runREPL(sections, os.Stdin, os.Stdout); replError != nil {
+			fatal(mode, replError)
+		}
+		writeWarnings(mode)
+		return
+	}
+
+	output, commandError := runCommand(sections, cmd)
+	if commandError == nil && strict && len(diagnostics) > 0 {
+		fatal(mode, strictError(len(diagnostics)))
+	}
+	if output != "" {
+		fmt.Println(output)
+	}
 	if commandError != nil {
-		log.Fatal(commandError)
+		fatal(mode, commandError)
 	}
-	fmt.Println(output)
+	writeWarnings(mode)
 }
 
-func initializeSections() ([]section) {
-	sections := []section{
-		{
-			name: "Variables",
-			short: "var",
-			subsections: []subsection{
-				{name: "Declaration", content: fmt.Sprintf(
-					("%sVariable Declaration%s:\n\n" +
-				    "\t%svar%s %s<variableName> <type>%s = %s<value>%s\n" +
-					"\t%vconst%s %s<variableName> <type>%s = %s<value>%s\n\n" +
-					"\t%s<varaibleName>%s := %s<value>%s\n" +
-					"\t\t - The %s:=%s (Walrus) operator is a shorthand for declaring and initializing a variable using type inference\n"),
-					BoldItalic, Reset, // Variable Declaration
-					Cyan, Reset, // var
-					Yellow, Reset, // <variableName> <type>
-					Green, Reset, // <value>
-					Cyan, Reset, // const
-					Yellow, Reset, // <variableName> <type>
-					Green, Reset, // <value>
-					Cyan, Reset, // <variableName>
-					Green, Reset, // <value>
-					BoldPurple, Reset, // :=
-				)},
-				{name: "Types", content: fmt.Sprintf(
-					("%sBasic Types%s:\n\n" +
-					"\t%sbool%s\n" + 
-					"\t%sstring%s\n" +
-					"\t%sint%s, %sint8%s, %sint16%s, %sint32%s, %sint64%s\n" +
-					"\t%suint%s, %suint8%s, %suint16%s, %suint32%s, %suint64%s\n" +
-					"\t%sfloat32%s, %sfloat64%s\n" +
-					"\t%scomplex64%s, %scomplex128%s\n" +
-					"\t%sbyte%s (alias for uint8)\n" +
-					"\t%srune%s (alias for int32, represents Unicode code point)\n"),
-					BoldItalic, Reset, // Basic Types
-					Yellow, Reset, // bool
-					Yellow, Reset, // string
-					Yellow, Reset, Yellow, Reset, Yellow, Reset, Yellow, Reset, Yellow, Reset, // int types
-					Yellow, Reset, Yellow, Reset, Yellow, Reset, Yellow, Reset, Yellow, Reset, // uint types
-					Yellow, Reset, Yellow, Reset, // float types
-					Yellow, Reset, Yellow, Reset, // complex types
-					Yellow, Reset, // byte
-					Yellow, Reset, // rune
-				)},
-			},
-		},
-		{
-			name: "Conditionals",
-			short: "cond",
-			subsections: []subsection{
-				{name: "If", content: fmt.Sprintf(
-					("%sIf Statement%s:\n\n" +
-					"\t%sif%s %s<condition>%s {\n" +
-					"\t\t// code\n" +
-					"\t}\n\n" +
-					"\t// With initialization statement\n" +
-					"\t%sif%s %s<initialization>%s; %s<condition>%s {\n" +
-					"\t\t// code\n" +
-					"\t}\n"),
-					BoldItalic, Reset, // If Statement
-					Cyan, Reset, // if
-					BoldPurple, Reset, // <condition>
-					Cyan, Reset, // if
-					Green, Reset, // <initialization>
-					BoldPurple, Reset, // <condition>
-				)},
-				{name: "IfElse", content: fmt.Sprintf(
-					("%sIf-Else Statement%s:\n\n" +
-					"\t%sif%s %s<condition>%s {\n" +
-					"\t\t// code if condition is true\n" +
-					"\t} %selse%s {\n" +
-					"\t\t// code if condition is false\n" +
-					"\t}\n"),
-					BoldItalic, Reset, // If-Else Statement
-					Cyan, Reset, // if
-					BoldPurple, Reset, // <condition>
-					Cyan, Reset, // else
-				)},
-				{name: "ElseIf", content: fmt.Sprintf(
-					("%sElse-If Statement%s:\n\n" +
-					"\t%sif%s %s<condition1>%s {\n" +
-					"\t\t// code if condition1 is true\n" +
-					"\t} %selse if%s %s<condition2>%s {\n" +
-					"\t\t// code if condition2 is true\n" +
-					"\t} %selse%s {\n" +
-					"\t\t// code if all conditions are false\n" +
-					"\t}\n"),
-					BoldItalic, Reset, // Else-If Statement
-					Cyan, Reset, // if
-					BoldPurple, Reset, // <condition1>
-					Cyan, Reset, // else if
-					BoldPurple, Reset, // <condition2>
-					Cyan, Reset, // else
-				)},
-				{name: "Switch", content: fmt.Sprintf(
-					("%sSwitch Statement%s:\n\n" +
-					"\t%sswitch%s %s<expression>%s {\n" +
-					"\t%scase%s %s<value1>%s:\n" +
-					"\t\t// code if expression == value1\n" +
-					"\t%scase%s %s<value2>%s, %s<value3>%s:\n" +
-					"\t\t// code if expression == value2 or expression == value3\n" +
-					"\t%sdefault%s:\n" +
-					"\t\t// code if no case matches\n" +
-					"\t}\n\n" + 
-					"\t// With initialization\n" +
-					"\t%sswitch%s %s<initialization>%s; %s<expression>%s {\n" +
-					"\t\t// cases\n" +
-					"\t}\n"),
-					BoldItalic, Reset, // Switch Statement
-					Cyan, Reset, // switch
-					Yellow, Reset, // <expression>
-					Cyan, Reset, // case
-					Green, Reset, // <value1>
-					Cyan, Reset, // case
-					Green, Reset, Green, Reset, // <value2>, <value3>
-					Cyan, Reset, // default
-					Cyan, Reset, // switch
-					Green, Reset, // <initialization>
-					Yellow, Reset, // <expression>
-				)},
-				{name: "TypeSwitch", content: fmt.Sprintf(
-					("%sType Switch%s:\n\n" +
-					"\t%sswitch%s %s<variable>%s := %s<expression>%s.(type) {\n" +
-					"\t%scase%s %sint%s:\n" +
-					"\t\t// code if expression is an int\n" +
-					"\t%scase%s %sstring%s:\n" +
-					"\t\t// code if expression is a string\n" +
-					"\t%scase%s %snil%s:\n" +
-					"\t\t// code if expression is nil\n" +
-					"\t%sdefault%s:\n" +
-					"\t\t// code for any other type\n" +
-					"\t}\n"),
-					BoldItalic, Reset, // Type Switch
-					Cyan, Reset, // switch
-					Yellow, Reset, // <variable>
-					Yellow, Reset, // <expression>
-					Cyan, Reset, // case
-					Yellow, Reset, // int
-					Cyan, Reset, // case
-					Yellow, Reset, // string
-					Cyan, Reset, // case
-					Yellow, Reset, // nil
-					Cyan, Reset, // default
-				)},
-			},
-		},
-		{
-			name: "Loops",
-			short: "loop",
-			subsections: []subsection{
-				{name: "For", content: fmt.Sprintf(
-					("%sFor Loop (Standard)%s:\n\n" +
-					"\t%sfor%s %s<initialization>%s; %s<condition>%s; %s<post>%s {\n" +
-					"\t\t// code\n" +
-					"\t}\n\n" +
-					"\t// Examples:\n" +
-					"\t%sfor%s %si%s := 0; i < 10; i++ {\n" +
-					"\t\t// code\n" +
-					"\t}\n"),
-					BoldItalic, Reset, // For Loop
-					Cyan, Reset, // for
-					Green, Reset, // <initialization>
-					BoldPurple, Reset, // <condition>
-					Green, Reset, // <post>
-					Cyan, Reset, // for
-					Yellow, Reset, // i
-				)},
-				{name: "WhileStyle", content: fmt.Sprintf(
-					("%sFor Loop (While Style)%s:\n\n" +
-					"\t%sfor%s %s<condition>%s {\n" +
-					"\t\t// code\n" +
-					"\t}\n\n" +
-					"\t// Example:\n" +
-					"\t%sfor%s %si < 10%s {\n" +
-					"\t\t// code\n" +
-					"\t\ti++\n" +
-					"\t}\n"),
-					BoldItalic, Reset, // While Style
-					Cyan, Reset, // for
-					BoldPurple, Reset, // <condition>
-					Cyan, Reset, // for
-					BoldPurple, Reset, // i < 10
-				)},
-				{name: "Infinite", content: fmt.Sprintf(
-					("%sInfinite Loop%s:\n\n" +
-					"\t%sfor%s {\n" +
-					"\t\t// code runs indefinitely\n" +
-					"\t\t%sif%s %s<condition>%s {\n" +
-					"\t\t\t%sbreak%s\n" +
-					"\t\t}\n" +
-					"\t}\n"),
-					BoldItalic, Reset, // Infinite Loop
-					Cyan, Reset, // for
-					Cyan, Reset, // if
-					BoldPurple, Reset, // <condition>
-					BoldYellow, Reset, // break
-				)},
-				{name: "Range", content: fmt.Sprintf(
-					("%sFor Range Loop%s:\n\n" +
-					"\t%sfor%s %s<index>%s, %s<value>%s := %srange%s %s<collection>%s {\n" +
-					"\t\t// code\n" +
-					"\t}\n\n" +
-					"\t// Ignore index with _\n" +
-					"\t%sfor%s %s_%s, %s<value>%s := %srange%s %s<collection>%s {\n" +
-					"\t\t// code\n" +
-					"\t}\n\n" +
-					"\t// Ignore value\n" +
-					"\t%sfor%s %s<index>%s, %s_%s := %srange%s %s<collection>%s {\n" +
-					"\t\t// code\n" +
-					"\t}\n\n" +
-					"\t// Only index\n" +
-					"\t%sfor%s %s<index>%s := %srange%s %s<collection>%s {\n" +
-					"\t\t// code\n" +
-					"\t}\n"),
-					BoldItalic, Reset, // For Range Loop
-					Cyan, Reset, // for
-					Yellow, Reset, // <index>
-					Yellow, Reset, // <value>
-					Cyan, Reset, // range
-					Green, Reset, // <collection>
-					Cyan, Reset, // for
-					Yellow, Reset, // _
-					Yellow, Reset, // <value>
-					Cyan, Reset, // range
-					Green, Reset, // <collection>
-					Cyan, Reset, // for
-					Yellow, Reset, // <index>
-					Yellow, Reset, // _
-					Cyan, Reset, // range
-					Green, Reset, // <collection>
-					Cyan, Reset, // for
-					Yellow, Reset, // <index>
-					Cyan, Reset, // range
-					Green, Reset, // <collection>
-				)},
-				{name: "ControlFlow", content: fmt.Sprintf(
-					("%sLoop Control Flow%s:\n\n" +
-					"\t%sbreak%s - Exit the loop immediately\n" +
-					"\t%scontinue%s - Skip the current iteration and move to the next one\n" +
-					"\t%sbreak <label>%s - Break out of the labeled loop (for nested loops)\n" +
-					"\t%scontinue <label>%s - Continue to next iteration of labeled loop\n\n" +
-					"\t// Example with label:\n" +
-					"\t%sOuterLoop%s:\n" +
-					"\t%sfor%s i := 0; i < 5; i++ {\n" +
-					"\t\t%sfor%s j := 0; j < 5; j++ {\n" +
-					"\t\t\t%sif%s j == 3 {\n" +
-					"\t\t\t\t%sbreak OuterLoop%s\n" +
-					"\t\t\t}\n" +
-					"\t\t}\n" +
-					"\t}\n"),
-					BoldItalic, Reset, // Loop Control Flow
-					BoldYellow, Reset, // break
-					BoldYellow, Reset, // continue
-					BoldYellow, Reset, // break <label>
-					BoldYellow, Reset, // continue <label>
-					BoldCyan, Reset, // OuterLoop:
-					Cyan, Reset, // for
-					Cyan, Reset, // for
-					Cyan, Reset, // if
-					BoldYellow, Reset, // break OuterLoop
-				)},
-			},
-		},
-		{
-			name: "Functions",
-			short: "func",
-			subsections: []subsection{
-				{name: "Declaration", content: fmt.Sprintf(
-					"%sFunction Declaration%s:\n\n"+
-					"\tfunc %sadd%s(%sa%s, %sb%s int) int {\n"+
-					"\t\treturn %sa%s + %sb%s\n"+
-					"\t}\n\n"+
-					"\tfunc %sswap%s(%sa%s, %sb%s string) (%sstring%s, %sstring%s) {\n"+
-					"\t\treturn %sb%s, %sa%s\n"+
-					"\t}",
-					BoldItalic, Reset,
-					Cyan, Reset, Yellow, Reset, Yellow, Reset,
-					Yellow, Reset, Yellow, Reset,
-					Cyan, Reset, Yellow, Reset, Yellow, Reset,
-					Yellow, Reset, Yellow, Reset,
-					Yellow, Reset, Yellow, Reset,
-				)},
-				{name: "Variadic", content: fmt.Sprintf(
-					"%sVariadic Functions%s:\n\n"+
-					"\tfunc %ssum%s(%snums%s ...int) int {\n"+
-					"\t\ttotal := 0\n"+
-					"\t\tfor _, %sv%s := range %snums%s {\n"+
-					"\t\t\ttotal += %sv%s\n"+
-					"\t\t}\n"+
-					"\t\treturn total\n"+
-					"\t}",
-					BoldItalic, Reset,
-					Cyan, Reset, Yellow, Reset,
-					Yellow, Reset, Yellow, Reset,
-					Yellow, Reset,
-				)},
-				{name: "Closures", content: fmt.Sprintf(
-					"%sClosures%s:\n\n"+
-					"\tfunc %sintSeq%s() func() int {\n"+
-					"\t\t%si%s := 0\n"+
-					"\t\treturn func() int {\n"+
-					"\t\t\t%si%s++\n"+
-					"\t\t\treturn %si%s\n"+
-					"\t\t}\n"+
-					"\t}",
-					BoldItalic, Reset,
-					Cyan, Reset,
-					Yellow, Reset,
-					Yellow, Reset,
-					Yellow, Reset,
-				)},
-			},
-		},
-		{
-			name: "DataStructures",
-			short: "ds",
-			subsections: []subsection{
-				{name: "Slices", content: fmt.Sprintf(
-					("%sSlices%s:\n\n" +
-					"\t// %sDeclaration%s\n" +
-					"\t%svar%s %s<name>%s []%s<type>%s\n" +
-					"\t%s<name>%s := []%s<type>%s{%s<values>%s}\n" +
-					"\t%s<name>%s := %smake%s([]%s<type>%s, %s<length>%s, %s<capacity>%s)\n\n" +
-					"\t// %sAccessing Elements%s\n" +
-					"\t%selement%s := %s<slice>%s[%s<index>%s]\n\n" +
-					"\t// %sSlicing%s\n" +
-					"\t%s<newSlice>%s := %s<slice>%s[%s<start>%s:%s<end>%s]\n" +
-					"\t%s<newSlice>%s := %s<slice>%s[%s<start>%s:%s<end>%s:%s<capacity>%s]\n\n" +
-					"\t// %sOperations%s\n" +
-					"\t%s<slice>%s = %sappend%s(%s<slice>%s, %s<element1>%s, %s<element2>%s)\n" +
-					"\t%s<length>%s := %slen%s(%s<slice>%s)\n" +
-					"\t%s<capacity>%s := %scap%s(%s<slice>%s)\n" +
-					"\t%scopy%s(%s<dst>%s, %s<src>%s)\n\n" +
-					"\t// %sIteration%s\n" +
-					"\t%sfor%s %s<index>%s, %s<value>%s := %srange%s %s<slice>%s {\n" +
-					"\t\t// code\n" +
-					"\t}\n"),
-					BoldItalic, Reset, // Slices
-					BoldUnderline, Reset, // Declaration
-					Cyan, Reset, // var
-					Yellow, Reset, // <name>
-					Yellow, Reset, // <type>
-					Yellow, Reset, // <name>
-					Yellow, Reset, // <type>
-					Green, Reset, // <values>
-					Yellow, Reset, // <name>
-					Cyan, Reset, // make
-					Yellow, Reset, // <type>
-					Green, Reset, // <length>
-					Green, Reset, // <capacity>
-					BoldUnderline, Reset, // Accessing Elements
-					Yellow, Reset, // element
-					Yellow, Reset, // <slice>
-					Green, Reset, // <index>
-					BoldUnderline, Reset, // Slicing
-					Yellow, Reset, // <newSlice>
-					Yellow, Reset, // <slice>
-					Green, Reset, // <start>
-					Green, Reset, // <end>
-					Yellow, Reset, // <newSlice>
-					Yellow, Reset, // <slice>
-					Green, Reset, // <start>
-					Green, Reset, // <end>
-					Green, Reset, // <capacity>
-					BoldUnderline, Reset, // Operations
-					Yellow, Reset, // <slice>
-					Cyan, Reset, // append
-					Yellow, Reset, // <slice>
-					Green, Reset, // <element1>
-					Green, Reset, // <element2>
-					Yellow, Reset, // <length>
-					Cyan, Reset, // len
-					Yellow, Reset, // <slice>
-					Yellow, Reset, // <capacity>
-					Cyan, Reset, // cap
-					Yellow, Reset, // <slice>
-					Cyan, Reset, // copy
-					Yellow, Reset, // <dst>
-					Yellow, Reset, // <src>
-					BoldUnderline, Reset, // Iteration
-					Cyan, Reset, // for
-					Yellow, Reset, // <index>
-					Yellow, Reset, // <value>
-					Cyan, Reset, // range
-					Yellow, Reset, // <slice>
-				)},
-				{name: "Maps", content: fmt.Sprintf(
-					("%sMaps%s:\n\n" +
-					"\t// %sDeclaration%s\n" +
-					"\t%svar%s %s<name>%s map[%s<keyType>%s]%s<valueType>%s\n" +
-					"\t%s<name>%s := map[%s<keyType>%s]%s<valueType>%s{%s<key1>%s: %s<value1>%s, %s<key2>%s: %s<value2>%s}\n" +
-					"\t%s<name>%s := %smake%s(map[%s<keyType>%s]%s<valueType>%s, %s<capacity>%s)\n\n" +
-					"\t// %sAccessing Elements%s\n" +
-					"\t%s<value>%s := %s<map>%s[%s<key>%s]\n" +
-					"\t%s<value>%s, %s<exists>%s := %s<map>%s[%s<key>%s] // Check if key exists\n\n" +
-					"\t// %sModifying%s\n" +
-					"\t%s<map>%s[%s<key>%s] = %s<value>%s // Add or update\n" +
-					"\t%sdelete%s(%s<map>%s, %s<key>%s) // Remove\n\n" +
-					"\t// %sOperations%s\n" +
-					"\t%s<length>%s := %slen%s(%s<map>%s)\n\n" +
-					"\t// %sIteration%s\n" +
-					"\t%sfor%s %s<key>%s, %s<value>%s := %srange%s %s<map>%s {\n" +
-					"\t\t// code\n" +
-					"\t}\n"),
-					BoldItalic, Reset, // Maps
-					BoldUnderline, Reset, // Declaration
-					Cyan, Reset, // var
-					Yellow, Reset, // <name>
-					Yellow, Reset, // <keyType>
-					Yellow, Reset, // <valueType>
-					Yellow, Reset, // <name>
-					Yellow, Reset, // <keyType>
-					Yellow, Reset, // <valueType>
-					Green, Reset, // <key1>
-					Green, Reset, // <value1>
-					Green, Reset, // <key2>
-					Green, Reset, // <value2>
-					Yellow, Reset, // <name>
-					Cyan, Reset, // make
-					Yellow, Reset, // <keyType>
-					Yellow, Reset, // <valueType>
-					Green, Reset, // <capacity>
-					BoldUnderline, Reset, // Accessing Elements
-					Yellow, Reset, // <value>
-					Yellow, Reset, // <map>
-					Green, Reset, // <key>
-					Yellow, Reset, // <value>
-					Yellow, Reset, // <exists>
-					Yellow, Reset, // <map>
-					Green, Reset, // <key>
-					BoldUnderline, Reset, // Modifying
-					Yellow, Reset, // <map>
-					Green, Reset, // <key>
-					Green, Reset, // <value>
-					Cyan, Reset, // delete
-					Yellow, Reset, // <map>
-					Green, Reset, // <key>
-					BoldUnderline, Reset, // Operations
-					Yellow, Reset, // <length>
-					Cyan, Reset, // len
-					Yellow, Reset, // <map>
-					BoldUnderline, Reset, // Iteration
-					Cyan, Reset, // for
-					Yellow, Reset, // <key>
-					Yellow, Reset, // <value>
-					Cyan, Reset, // range
-					Yellow, Reset, // <map>
-				)},
-				{name: "Structs", content: fmt.Sprintf(
-					("%sStructs%s:\n\n" +
-					"\t// %sDefinition%s\n" +
-					"\t%stype%s %s<Name>%s struct {\n" +
-					"\t\t%s<field1>%s %s<type1>%s\n" +
-					"\t\t%s<field2>%s %s<type2>%s\n" +
-					"\t\t%s<field3>%s %s<type3>%s `%s<tag>%s`\n" +
-					"\t}\n\n" +
-					"\t// %sCreation%s\n" +
-					"\t%s<var1>%s := %s<Name>%s{%s<field1>%s: %s<value1>%s, %s<field2>%s: %s<value2>%s}\n" +
-					"\t%s<var2>%s := %s<Name>%s{%s<value1>%s, %s<value2>%s} // Positional initialization\n" +
-					"\t%s<var3>%s := new(%s<Name>%s) // Zero-initialized\n\n" +
-					"\t// %sAccessing Fields%s\n" +
-					"\t%s<value>%s := %s<struct>%s.%s<field>%s\n" +
-					"\t%s<struct>%s.%s<field>%s = %s<newValue>%s\n\n" +
-					"\t// %sPointer to Struct%s\n" +
-					"\t%s<ptr>%s := &%s<struct>%s\n" +
-					"\t%s<value>%s := %s<ptr>%s.%s<field>%s // Automatic dereferencing\n\n" +
-					"\t// %sMethods of Structs%s\n" +
-					"\t%sfunc%s (%s<receiver>%s %s<Name>%s) %s<methodName>%s(%s<param>%s %s<type>%s) %s<returnType>%s {\n" +
-					"\t\t// code\n" +
-					"\t}\n" +
-					"\t%s<receiver>%s.%s<methodName>%s(%s<param>%s)\n"),
-					BoldItalic, Reset, // Structs
-					BoldUnderline, Reset, // Definition
-					Cyan, Reset, // type
-					Yellow, Reset, // <Name>
-					Yellow, Reset, // <field1>
-					Yellow, Reset, // <type1>
-					Yellow, Reset, // <field2>
-					Yellow, Reset, // <type2>
-					Yellow, Reset, // <field3>
-					Yellow, Reset, // <type3>
-					Italic, Reset, // <tag>
-					BoldUnderline, Reset, // Creation
-					Yellow, Reset, // <var1>
-					Yellow, Reset, // <Name>
-					Yellow, Reset, // <field1>
-					Green, Reset, // <value1>
-					Yellow, Reset, // <field2>
-					Green, Reset, // <value2>
-					Yellow, Reset, // <var2>
-					Yellow, Reset, // <Name>
-					Green, Reset, // <value1>
-					Green, Reset, // <value2>
-					Yellow, Reset, // <var3>
-					Yellow, Reset, // <Name>
-					BoldUnderline, Reset, // Accessing Fields
-					Yellow, Reset, // <value>
-					Yellow, Reset, // <struct>
-					Yellow, Reset, // <field>
-					Yellow, Reset, // <struct>
-					Yellow, Reset, // <field>
-					Green, Reset, // <newValue>
-					BoldUnderline, Reset, // Pointer to Struct
-					Yellow, Reset, // <ptr>
-					Yellow, Reset, // <struct>
-					Yellow, Reset, // <value>
-					Yellow, Reset, // <ptr>
-					Yellow, Reset, // <field>
-					BoldUnderline, Reset, // Methods of Structs
-					Cyan, Reset, // func
-					Yellow, Reset, // <receiver>
-					Yellow, Reset, // <Name>
-					Yellow, Reset, // <methodName>
-					Yellow, Reset, // <param>
-					Yellow, Reset, // <type>
-					Yellow, Reset, // <returnType>
-					Cyan, Reset, // <receiver>
-					Yellow, Reset, // <methodName>
-					Yellow, Reset, // <param>
-				)},
-				{name: "Interfaces", content: fmt.Sprintf(
-					("%sInterfaces%s:\n\n" +
-					"\t// %sDefinition%s\n" +
-					"\t%stype%s %s<Name>%s interface {\n" +
-					"\t\t%s<Method1>%s(%s<param1>%s %s<type1>%s) %s<returnType1>%s\n" +
-					"\t\t%s<Method2>%s(%s<param2>%s %s<type2>%s, %s<param3>%s %s<type3>%s) (%s<returnType2>%s, %s<returnType3>%s)\n" +
-					"\t}\n\n" +
-					"\t// %sEmpty Interface%s\n" +
-					"\t%svar%s %s<anything>%s interface{}\n\n" +
-					"\t// %sImplementation%s (implicit, no \"implements\" keyword)\n" +
-					"\t%stype%s %s<StructName>%s struct {\n" +
-					"\t\t// fields\n" +
-					"\t}\n\n" +
-					"\t%sfunc%s (%s<receiver>%s %s<StructName>%s) %s<Method1>%s(%s<param1>%s %s<type1>%s) %s<returnType1>%s {\n" +
-					"\t\t// implementation\n" +
-					"\t}\n\n" +
-					"\t// %sType Assertion%s\n" +
-					"\t%s<value>%s := %s<interfaceVar>%s.(%s<Type>%s) // Panics if wrong type\n" +
-					"\t%s<value>%s, %s<ok>%s := %s<interfaceVar>%s.(%s<Type>%s) // Safe checking\n\n" +
-					"\t// %sType Switch%s\n" +
-					"\t%sswitch%s %s<v>%s := %s<interfaceVar>%s.(type) {\n" +
-					"\t%scase%s %s<Type1>%s:\n" +
-					"\t\t// v is Type1\n" +
-					"\t%scase%s %s<Type2>%s:\n" +
-					"\t\t// v is Type2\n" +
-					"\t%sdefault%s:\n" +
-					"\t\t// unknown type\n" +
-					"\t}\n"),
-					BoldItalic, Reset, // Interfaces
-					BoldUnderline, Reset, // Definition
-					Cyan, Reset, // type
-					Yellow, Reset, // <Name>
-					Yellow, Reset, // <Method1>
-					Yellow, Reset, // <param1>
-					Yellow, Reset, // <type1>
-					Yellow, Reset, // <returnType1>
-					Yellow, Reset, // <Method2>
-					Yellow, Reset, // <param2>
-					Yellow, Reset, // <type2>
-					Yellow, Reset, // <param3>
-					Yellow, Reset, // <type3>
-					Yellow, Reset, // <returnType2>
-					Yellow, Reset, // <returnType3>
-					BoldUnderline, Reset, // Empty Interface
-					Cyan, Reset, // var
-					Yellow, Reset, // <anything>
-					BoldUnderline, Reset, // Implementation
-					Cyan, Reset, // type
-					Yellow, Reset, // <StructName>
-					Cyan, Reset, // func
-					Yellow, Reset, // <receiver>
-					Yellow, Reset, // <StructName>
-					Yellow, Reset, // <Method1>
-					Yellow, Reset, // <param1>
-					Yellow, Reset, // <type1>
-					Yellow, Reset, // <returnType1>
-					BoldUnderline, Reset, // Type Assertion
-					Yellow, Reset, // <value>
-					Yellow, Reset, // <interfaceVar>
-					Yellow, Reset, // <Type>
-					Yellow, Reset, // <value>
-					Yellow, Reset, // <ok>
-					Yellow, Reset, // <interfaceVar>
-					Yellow, Reset, // <Type>
-					BoldUnderline, Reset, // Type Switch
-					Cyan, Reset, // switch
-					Yellow, Reset, // <v>
-					Yellow, Reset, // <interfaceVar>
-					Cyan, Reset, // case
-					Yellow, Reset, // <Type1>
-					Cyan, Reset, // case
-					Yellow, Reset, // <Type2>
-					Cyan, Reset, // default
-				)},
-			},
-		},
-		{
-			name: "Channels",
-			short: "chan",
-			subsections: []subsection{
-				{name: "Buffered", content: fmt.Sprintf(
-					("%sBuffered Channels%s:\n\n"+
-						"\t%sch%s := %smake%s(chan %sint%s, %s3%s)\n"+
-						"\t%sch%s <- %s1%s  %s// Non-blocking until buffer full%s\n"+
-						"\t%sch%s <- %s2%s\n"+
-						"\t%sclose%s(%sch%s)\n"+
-						"\t%sfor%s %sv%s := %srange%s %sch%s {\n"+
-						"\t\t%sfmt.Println%s(%sv%s)\n"+
-						"\t}\n"),
-					BoldItalic, Reset, // Buffered Channels
-					Yellow, Reset, Cyan, Reset, Yellow, Reset, Green, Reset, // ch make int 3
-					Yellow, Reset, Green, Reset, Cyan, Reset, // ch 1 //...full
-					Yellow, Reset, Green, Reset, // ch 2
-					Cyan, Reset, Yellow, Reset, // close ch
-					Cyan, Reset, Yellow, Reset, Cyan, Reset, Yellow, Reset, // for v range ch
-					Cyan, Reset, Yellow, Reset, // fmt.Println v
-				)},
-				{name: "Select", content: fmt.Sprintf(
-					("%sSelect Statement%s:\n\n"+
-						"\t%sselect%s {\n"+
-						"\t%scase%s %smsg%s := <-%sch1%s:\n"+
-						"\t\t%sfmt.Println%s(%smsg%s)\n"+
-						"\t%scase%s %sch2%s <- %s3%s:\n"+
-						"\t\t%sfmt.Println%s(%s\"sent\"%s)\n"+
-						"\t%sdefault%s:\n"+
-						"\t\t%sfmt.Println%s(%s\"no activity\"%s)\n"+
-						"\t}\n"),
-					BoldItalic, Reset, // Select Statement
-					Cyan, Reset, // select
-					Cyan, Reset, Yellow, Reset, Yellow, Reset, // case msg := <-ch1:
-					Cyan, Reset, Yellow, Reset, // fmt.Println msg
-					Cyan, Reset, Yellow, Reset, Yellow, Reset, // case ch2 <- 3:
-					Cyan, Reset, Cyan, Reset, // fmt.Println "sent"
-					Cyan, Reset, Cyan, Reset, Green, Reset, // default:
-				)},
-				{name: "Looping", content: fmt.Sprintf(
-					("%sLooping Through Channels%s:\n\n"+
-						"\t%sfor%s {\n"+
-						"\t\t%smsg%s, %sok%s := <-%sch%s\n"+
-						"\t\t%sif%s !%sok%s {\n"+
-						"\t\t\t%sbreak%s\n"+
-						"\t\t}\n"+
-						"\t\t%sfmt.Println%s(%smsg%s)\n"+
-						"\t}\n\n"+
-						"\t%sfor%s %smsg%s := %srange%s %sch%s {\n"+
-						"\t\t%sfmt.Println%s(%smsg%s)\n"+
-						"\t}\n"),
-					BoldItalic, Reset, // Looping Through Channels
-					Cyan, Reset, // for
-					Yellow, Reset, Yellow, Reset, Yellow, Reset, // msg, ok := <-ch
-					Cyan, Reset, Yellow, Reset, // if !ok
-					BoldYellow, Reset, // break
-					Cyan, Reset, Yellow, Reset, // fmt.Println msg
-					Cyan, Reset, Yellow, Reset, Cyan, Reset, Yellow, Reset, // for msg := range ch
-					Cyan, Reset, Yellow, Reset, // fmt.Println msg
-				)},
-			},
-		},
-		{
-			name: "Goroutines",
-			short: "goroutine",
-			subsections: []subsection{
-				{name: "Basic", content: fmt.Sprintf(
-					("%sStarting Goroutines%s:\n\n"+
-						"\t%sgo%s %sfunc%s() {\n"+
-						"\t\t%sfmt.Println%s(%s\"Running\"%s)\n"+
-						"\t}()\n"),
-					BoldItalic, Reset,
-					Cyan, Reset, Cyan, Reset,
-					Cyan, Reset, Green, Reset,
-				)},
-				{name: "WaitGroups", content: fmt.Sprintf(
-					("%sUsing WaitGroups%s:\n\n"+
-						"\tvar %swg%s sync.WaitGroup\n"+
-						"\t%swg%s.Add(%s1%s)\n"+
-						"\t%sgo%s func() {\n"+
-						"\t\t%sdefer%s %swg%s.Done()\n"+
-						"\t\t%sfmt.Println%s(%s\"Done\"%s)\n"+
-						"\t}()\n"+
-						"\t%swg%s.Wait()\n"),
-					BoldItalic, Reset, // Using WaitGroups
-					Yellow, Reset, // wg
-					Yellow, Reset, Green, Reset, // wg.Add(1)
-					Cyan, Reset, // go
-					Cyan, Reset, Yellow, Reset, // wg.Done()
-					Cyan, Reset, Green, Reset, // fmt.Println "Done"
-					Yellow, Reset, // wg
-				)},
-				{name: "Communication", content: fmt.Sprintf(
-					("%sChannel Communication%s:\n\n"+
-						"\t%sch%s := make(chan %sstring%s)\n"+
-						"\t%sgo%s func() {\n"+
-						"\t\t%sch%s <- %s\"ping\"%s\n"+
-						"\t}()\n"+
-						"\t%smsg%s := <-%sch%s\n"+
-						"\t%sfmt.Println%s(%smsg%s)\n"),
-					BoldItalic, Reset, // Channel Communication
-					Yellow, Reset, Yellow, Reset, // ch make string
-					Cyan, Reset, // go
-					Yellow, Reset, Green, Reset, // ch <- "ping"
-					Yellow, Reset, Yellow, Reset, // msg := <-ch
-					Cyan, Reset, Yellow, Reset,	// fmt.Println msg
-				)},
-			},
-		},
-		{
-			name: "Concurrency",
-			short: "concurrent",
-			subsections: []subsection{
-				{name: "Mutex", content: fmt.Sprintf(
-					"%sMutex Usage%s:\n\n"+
-					"\tvar %smux%s sync.Mutex\n"+
-					"\tvar %sval%s int\n\n"+
-					"\t%smux%s.Lock()\n"+
-					"\t%sval%s++\n"+
-					"\t%smux%s.Unlock()",
-					BoldItalic, Reset,
-					Yellow, Reset, 
-					Yellow, Reset,
-					Yellow, Reset,
-					Yellow, Reset,
-					Yellow, Reset,
-				)},
-				{name: "WorkerPool", content: fmt.Sprintf(
-					"%sWorker Pool%s:\n\n"+
-					"\t%sworker%s := func(%sjobs%s <-chan int, %sresults%s chan<- int) {\n"+
-					"\t\tfor %sj%s := range %sjobs%s {\n"+
-					"\t\t\t%sresults%s <- %sj%s * 2\n"+
-					"\t\t}\n"+
-					"\t}",
-					BoldItalic, Reset,
-					Cyan, Reset, Yellow, Reset, Yellow, Reset,
-					Yellow, Reset, Yellow, Reset,
-					Yellow, Reset, Yellow, Reset,
-				)},
-			},
-		},
-		{
-			name: "Pointers",
-			short: "ptr",
-			subsections: []subsection{
-				{name: "Basics", content: fmt.Sprintf(
-					("%sPointer Basics%s:\n\n"+
-						"\tvar %sp%s *%sint%s\n"+
-						"\t%si%s := %s42%s\n"+
-						"\t%sp%s = &%si%s\n"+
-						"\t%sfmt.Println%s(*%sp%s)  %s// 42%s\n"),
-					BoldItalic, Reset, // Pointer Basics
-					Yellow, Reset, Yellow, Reset, // p *int
-					Yellow, Reset, Green, Reset, // i := 42
-					Yellow, Reset, Yellow, Reset, // p = &i
-					Cyan, Reset, Yellow, Reset, Cyan, Reset, // fmt.Println *p
-				)},
-				{name: "Structs", content: fmt.Sprintf(
-					("%sPointers to Structs%s:\n\n"+
-						"\t%stype%s %sVertex%s struct { %sX%s, %sY%s float64 }\n"+
-						"\t%sv%s := %sVertex%s{%s1%s, %s2%s}\n"+
-						"\t%sp%s := &%sv%s\n"+
-						"\t%sp%s.%sX%s = %s1e9%s\n"),
-					BoldItalic, Reset, // Pointers to Structs
-					Cyan, Reset, Yellow, Reset, Yellow, Reset, Yellow, Reset, // type Vertex X Y
-					Yellow, Reset, Yellow, Reset, Green, Reset, Green, Reset, // v Vertex 1 2
-					Yellow, Reset, Yellow, Reset, // p &v
-					Yellow, Reset, Yellow, Reset, Green, Reset, // p X 1e9
-				)},
-				{name: "Functions", content: fmt.Sprintf(
-					("%sFunction Parameters%s:\n\n"+
-						"\tfunc %smodify%s(%sp%s *%sint%s) {\n"+
-						"\t\t*%sp%s = %s2%s\n"+
-						"\t}\n"+
-						"\t%si%s := %s1%s\n"+
-						"\t%smodify%s(&%si%s)\n"),
-					BoldItalic, Reset, // Function Parameters
-					Cyan, Reset, Yellow, Reset, Yellow, Reset, // modify p *int
-					Yellow, Reset, Green, Reset, // p = 2
-					Yellow, Reset, Green, Reset, // i := 1
-					Cyan, Reset, Yellow, Reset, // modify &i
-				)},
-			},
-		},
-		{
-			name: "ErrorHandling",
-			short: "err",
-			subsections: []subsection{
-				{name: "Basic", content: fmt.Sprintf(
-					("%sBasic Error Handling%s:\n\n"+
-						"\t%sfile%s, %serr%s := %sos.Open%s(%s\"file.txt\"%s)\n"+
-						"\t%sif%s %serr%s != %snil%s {\n"+
-						"\t\t%slog.Fatal%s(%serr%s)\n"+
-						"\t}\n"+
-						"\t%sdefer%s %sfile%s.Close()\n"),
-					BoldItalic, Reset, // Basic Error Handling
-					Yellow, Reset, Yellow, Reset, Cyan, Reset, Green, Reset, // file, err := os.Open
-					Cyan, Reset, Yellow, Reset, Cyan, Reset, // if err != nil
-					Cyan, Reset, Yellow, Reset, // log.Fatal err
-					Cyan, Reset, Yellow, Reset, // defer file.Close
-				)},
-				{name: "Custom", content: fmt.Sprintf(
-					("%sCustom Errors%s:\n\n"+
-						"\t%stype%s %sMyError%s struct {\n"+
-						"\t\t%sMsg%s string\n"+
-						"\t}\n\n"+
-						"\tfunc (%se%s *%sMyError%s) %sError%s() string {\n"+
-						"\t\treturn %se%s.%sMsg%s\n"+
-						"\t}\n"),
-					BoldItalic, Reset, // Custom Errors
-					Cyan, Reset, Yellow, Reset, // type MyError
-					Yellow, Reset, // Msg
-					Yellow, Reset, Yellow, Reset, Green, Reset, // e MyError Error
-					Cyan, Reset, Yellow, Reset, // e Msg
-				)},
-				{name: "PanicRecover", content: fmt.Sprintf(
-					("%sPanic and Recover%s:\n\n"+
-						"\tfunc %smayPanic%s() {\n"+
-						"\t\t%spanic%s(%s\"problem\"%s)\n"+
-						"\t}\n\n"+
-						"\t%sdefer%s func() {\n"+
-						"\t\tif %sr%s := %srecover%s(); %sr%s != nil {\n"+
-						"\t\t\t%sfmt.Println%s(%s\"Recovered:\"%s, %sr%s)\n"+
-						"\t\t}\n"+
-						"\t}()\n"+
-						"\t%smayPanic%s()\n"),
-					BoldItalic, Reset, // Panic and Recover
-					Cyan, Reset, Green, Reset, // mayPanic
-					Cyan, Reset, Green, Reset, // panic "problem"
-					Cyan, Reset, // defer
-					Yellow, Reset, Cyan, Reset, Yellow, Reset, // r recover r 
-					Cyan, Reset, Green, Reset, // fmt.Println "Recovered:"
-					Cyan, Reset, //mayPanic 
-				)},
-			},
-		},
-		{
-			name: "Testing",
-			short: "test",
-			subsections: []subsection{
-				{name: "UnitTests", content: fmt.Sprintf(
-					"%sUnit Test%s:\n\n"+
-					"\tfunc %sTestAdd%s(%st%s *testing.T) {\n"+
-					"\t\tgot := %sadd%s(2, 3)\n"+
-					"\t\twant := 5\n"+
-					"\t\t%sif%s got != want {\n"+
-					"\t\t\t%st%s.Errorf(%s\"got %%d want %%d\"%s, got, want)\n"+
-					"\t\t}\n"+
-					"\t}",
-					BoldItalic, Reset,
-					Cyan, Reset, Yellow, Reset,
-					Cyan, Reset,
-					Cyan, Reset,
-					Yellow, Reset, Green, Reset,
-				)},
-				{name: "Benchmarks", content: fmt.Sprintf(
-					"%sBenchmark%s:\n\n"+
-					"\tfunc %sBenchmarkAdd%s(%sb%s *testing.B) {\n"+
-					"\t\tfor %si%s := 0; %si%s < %sb%s.N; %si%s++ {\n"+
-					"\t\t\t%sadd%s(1, 2)\n"+
-					"\t\t}\n"+
-					"\t}",
-					BoldItalic, Reset,
-					Cyan, Reset, Yellow, Reset,
-					Yellow, Reset, Yellow, Reset, Yellow, Reset, Yellow, Reset,
-					Cyan, Reset,
-				)},
-			},
-		},
-		{
-			name: "StringManipulation",
-			short: "str",
-			subsections: []subsection{
-				{name: "Basic", content: fmt.Sprintf(
-					("%sBasic Operations%s:\n\n"+
-						"\t%ss1%s := %s\"Hello\"%s\n"+
-						"\t%ss2%s := %s\"World\"%s\n"+
-						"\t%ss3%s := %ss1%s + %s\" \"%s + %ss2%s\n"+
-						"\t%sfmt.Println%s(%slen%s(%ss3%s))  %s// 11%s\n"),
-					BoldItalic, Reset, // Basic Operations
-					Yellow, Reset, Green, Reset, // s1 := "Hello"
-					Yellow, Reset, Green, Reset, // s2 := "World"
-					Yellow, Reset, Yellow, Reset, Green, Reset, Yellow, Reset, // s3 := s1 + " " + s2
-					Cyan, Reset, Cyan, Reset, Yellow, Reset, Cyan, Reset, // fmt.Println len s3
-				)},
-				{name: "StringsPackage", content: fmt.Sprintf(
-					("%sStrings Package%s:\n\n"+
-						"\t%sstrings.Split%s(%s\"a,b,c\"%s, %s\",\"%s)\n"+
-						"\t%sstrings.ToUpper%s(%s\"test\"%s)\n"+
-						"\t%sstrings.TrimSpace%s(%s\"  text  \"%s)\n"),
-					BoldItalic, Reset, // Strings Package
-					Cyan, Reset, Green, Reset, Green, Reset, // strings.Split
-					Cyan, Reset, Green, Reset, // strings.ToUpper
-					Cyan, Reset, Green, Reset, // strings.TrimSpace
-				)},
-				{name: "Conversions", content: fmt.Sprintf(
-					("%sType Conversions%s:\n\n"+
-						"\t%si%s, %s_%s := %sstrconv.Atoi%s(%s\"42\"%s)\n"+
-						"\t%ss%s := %sstrconv.Itoa%s(%s42%s)\n"),
-					BoldItalic, Reset, // Type Conversions
-					Yellow, Reset, Yellow, Reset, Cyan, Reset, Green, Reset, // i, _ := strconv.Atoi
-					Yellow, Reset, Cyan, Reset, Green, Reset, // s := strconv.Itoa
-				)},
-			},
-		},
-		{
-			name: "PrintFormatting",
-			short: "fmt",
-			subsections: []subsection{
-				{name: "PrintFunctions", content: fmt.Sprintf(
-					("%sPrint Functions%s:\n\n"+
-						"\t%sfmt.Print%s(%s\"Hello\"%s)\n"+
-						"\t%sfmt.Println%s(%s\"World\"%s)\n"+
-						"\t%sfmt.Printf%s(%s\"Value: %%v\"%s, %s42%s)\n"),
-					BoldItalic, Reset, // Print Functions
-					Cyan, Reset, Green, Reset, // fmt.Print "Hello"
-					Cyan, Reset, Green, Reset, // fmt.Println "World"
-					Cyan, Reset, Green, Reset, Green, Reset, // fmt.Printf "Value: %v"
-				)},
-				{name: "FormatVerbs", content: fmt.Sprintf(
-					("%sFormat Verbs%s:\n\n"+
-						"\t%s%%v%s - Value\n"+
-						"\t%s%%s%s - String\n"+
-						"\t%s%%d%s - Integer\n"+
-						"\t%s%%f%s - Float\n"+
-						"\t%s%%t%s - Boolean\n"+
-						"\t%s%%T%s - Type\n"),
-					BoldItalic, Reset, // Format Verbs
-					BoldPurple, Reset, // %v
-					BoldPurple, Reset, // %s
-					BoldPurple, Reset, // %d
-					BoldPurple, Reset, // %f
-					BoldPurple, Reset, // %t
-					BoldPurple, Reset, // %T
-				)},
-				{name: "Sprintf", content: fmt.Sprintf(
-					("%sString Formatting%s:\n\n"+
-						"\t%ss%s := %sfmt.Sprintf%s(%s\"Name: %%s, Age: %%d\"%s, %s\"Alice\"%s, %s30%s)\n"+
-						"\t%sfmt.Fprintf%s(%sos.Stderr%s, %s\"Error: %%v\"%s, %serr%s)\n"),
-					BoldItalic, Reset, // String Formatting
-					Yellow, Reset, Cyan, Reset, Green, Reset, Green, Reset, Green, Reset, // s := fmt.Sprintf
-					Cyan, Reset, Cyan, Reset, Green, Reset, Yellow, Reset, // fmt.Fprintf
-				)},
-			},
-		},
-		{
-			name: "FileIO",
-			short: "file",
-			subsections: []subsection{
-				{name: "ReadWrite", content: fmt.Sprintf(
-					"%sRead/Write Files%s:\n\n"+
-					"\t%sdata%s := []byte(%s\"hello\\nworld\"%s)\n"+
-					"\t%serr%s := %sos.WriteFile%s(%s\"file.txt\"%s, %sdata%s, 0644)\n\n"+
-					"\t%scontent%s, %serr%s := %sos.ReadFile%s(%s\"file.txt\"%s)",
-					BoldItalic, Reset,
-					Yellow, Reset, Green, Reset,
-					Yellow, Reset, Cyan, Reset, Green, Reset, Yellow, Reset,
-					Yellow, Reset, Yellow, Reset, Cyan, Reset, Green, Reset,
-				)},
-			},
-		},
-		{
-			name: "Time",
-			short: "time",
-			subsections: []subsection{
-				{name: "Formatting", content: fmt.Sprintf(
-					"%sTime Formatting%s:\n\n"+
-					"\t%st%s := %stime.Now%s()\n"+
-					"\t%sfmt.Println%s(%st%s.Format(%s\"2006-01-02 15:04:05\"%s))",
-					BoldItalic, Reset,
-					Yellow, Reset, Cyan, Reset,
-					Cyan, Reset, Yellow, Reset, Green, Reset,
-				)},
-			},
-		},
-		{
-			name: "HTTPServer",
-			short: "http",
-			subsections: []subsection{
-				{name: "BasicServer", content: fmt.Sprintf(
-					"%sBasic Server%s:\n\n"+
-					"\t%shttp.HandleFunc%s(%s\"/\"%s, func(%sw%s http.ResponseWriter, %sr%s *http.Request) {\n"+
-					"\t\t%sfmt.Fprintf%s(%sw%s, %s\"Hello World\"%s)\n"+
-					"\t})\n"+
-					"\t%shttp.ListenAndServe%s(%s\":8080\"%s, nil)",
-					BoldItalic, Reset,
-					Cyan, Reset, Green, Reset,
-					Yellow, Reset, Yellow, Reset,
-					Cyan, Reset, Yellow, Reset, Green, Reset,
-					Cyan, Reset, Green, Reset,
-				)},
-			},
-		},
-		{
-			name: "PackageManagement",
-			short: "pkg",
-			subsections: []subsection{
-				{name: "GoMod", content: fmt.Sprintf(
-					("%sgo.mod Example%s:\n\n"+
-						"\tmodule %sgithub.com/yourname/project%s\n\n"+
-						"\tgo %s1.21%s\n\n"+
-						"\trequire (\n"+
-						"\t\t%sgithub.com/pkg/errors%s %sv0.9.1%s\n"+
-						"\t)"),
-					BoldItalic, Reset, // go.mod Example
-					Green, Reset, // 1.21
-					Green, Reset, // github.com/pkg/errors
-					Green, Reset, Green, Reset, // v0.9.1
-				)},
-				{name: "Dependencies", content: fmt.Sprintf(
-					("%sDependency Management%s:\n\n"+
-						"\t%sgo get%s %sgithub.com/pkg/errors@latest%s\n"+
-						"\t%sgo mod tidy%s\n"+
-						"\t%sgo list -m all%s\n"+
-						"\t%sgo mod vendor%s"),
-					BoldItalic, Reset, // Dependency Management
-					Cyan, Reset, Green, Reset, // github.com/pkg/errors@latest
-					Cyan, Reset, // go mod tidy
-					Cyan, Reset, // go list -m all
-					Cyan, Reset, // go mod vendor
-				)},
-				{name: "Vendoring", content: fmt.Sprintf(
-					("%sVendor Directory%s:\n\n"+
-						"\t%sgo mod vendor%s\n"+
-						"\t%sgo build -mod=vendor%s\n"+
-						"\t%s// vendor/modules.txt contains dependency info%s"),
-					BoldItalic, Reset, // Vendor Directory
-					Cyan, Reset, // go mod vendor
-					Cyan, Reset, // go build -mod=vendor
-					Italic, Reset, // vendor/modules.txt
-				)},
-			},
-		},
-		{
-			name: "BuildRun",
-			short: "build",
-			subsections: []subsection{
-				{name: "Commands", content: fmt.Sprintf(
-					("%sBuild Commands%s:\n\n"+
-						"\t%sgo build%s %s./cmd/app%s\n"+
-						"\t%sgo run%s %smain.go%s\n"+
-						"\t%sgo install%s %sgithub.com/project/cmd/app%s\n"+
-						"\t%sGOOS=linux GOARCH=amd64 go build%s"),
-					BoldItalic, Reset, // Build Commands
-					Cyan, Reset, Green, Reset, // go build ./cmd/app
-					Cyan, Reset, Green, Reset, // go run main.go
-					Cyan, Reset, Green, Reset, // go install github.com/project/cmd/app
-					Cyan, Reset, // GOOS=linux GOARCH=amd64 go build
-				)},
-				{name: "MultiModule", content: fmt.Sprintf(
-					("%sLocal Modules%s:\n\n"+
-						"\t// go.work file\n"+
-						"\tuse (\n"+
-						"\t\t./lib\n"+
-						"\t\t./app\n"+
-						"\t)\n\n"+
-						"\t// go.mod\n"+
-						"\treplace %slocal/mypackage%s => %s../mypackage%s"),
-					BoldItalic, Reset, // Local Modules
-					Green, Reset, Green, Reset, // local/mypackage
-				)},
-			},
-		},
-		{
-			name: "Reflection",
-			short: "reflect",
-			subsections: []subsection{
-				{name: "Basic", content: fmt.Sprintf(
-					("%sBasic Reflection%s:\n\n"+
-						"\t%st%s := %sreflect.TypeOf%s(%s42%s)\n"+
-						"\t%sv%s := %sreflect.ValueOf%s(%s\"hello\"%s)\n"+
-						"\t%sfmt.Println%s(%st%s.Kind(), %sv%s.Len())"),
-					BoldItalic, Reset, // Basic Reflection
-					Yellow, Reset, Cyan, Reset, Green, Reset, // t := reflect.TypeOf
-					Yellow, Reset, Cyan, Reset, Green, Reset, // v := reflect.ValueOf
-					Cyan, Reset, Yellow, Reset, Yellow, Reset, // fmt.Println
-				)},
-				{name: "Structs", content: fmt.Sprintf(
-					("%sStruct Reflection%s:\n\n"+
-						"\t%stype%s %sPerson%s struct {\n"+
-						"\t\t%sName%s string\n"+
-						"\t}\n\n"+
-						"\t%sp%s := %sPerson%s{%s\"Alice\"%s}\n"+
-						"\t%sv%s := %sreflect.ValueOf%s(%sp%s)\n"+
-						"\t%sf%s := %sv%s.FieldByName(%s\"Name\"%s)"),
-					BoldItalic, Reset, // Struct Reflection
-					Cyan, Reset, Yellow, Reset, // type Person
-					Yellow, Reset, // Name 
-					Yellow, Reset, Yellow, Reset, Green, Reset, // p Person "Alice"
-					Yellow, Reset, Cyan, Reset, Yellow, Reset, // v reflect.ValueOf p
-					Yellow, Reset, Yellow, Reset, Green, Reset), // f v.FieldByName
-				},
-			},
-		},
-		{
-			name: "ImportsVisibility",
-			short: "imp",
-			subsections: []subsection{
-				{name: "Imports", content: fmt.Sprintf(
-					("%sImport Statements%s:\n\n"+
-						"\timport (\n"+
-						"\t\t%s\"fmt\"%s\n"+
-						"\t\t%s\"github.com/user/pkg\"%s\n"+
-						"\t\t%s\"./local\"%s\n"+
-						"\t)"),
-					BoldItalic, Reset, // Import Statements
-					Green, Reset, // fmt
-					Green, Reset, // github.com/user/pkg
-					Green, Reset, // ./local
-				)},
-				{name: "Visibility", content: fmt.Sprintf(
-					("%sPublic/Private%s:\n\n"+
-						"\t// Public (exported)\n"+
-						"\t%svar%s %sGlobalVar%s int\n"+
-						"\t%sfunc%s %sPublicFunc%s() {}\n\n"+
-						"\t// Private (unexported)\n"+
-						"\t%svar%s %slocalVar%s int\n"+
-						"\t%sfunc%s %sprivateFunc%s() {}"),
-					BoldItalic, Reset, // Public/Private
-					Cyan, Reset, Yellow, Reset, // var GlobalVar
-					Cyan, Reset, Yellow, Reset, // func PublicFunc
-					Cyan, Reset, Yellow, Reset, // var localVar
-					Cyan, Reset, Yellow, Reset, // func privateFunc
-				)},
-			},
-		},
-		{
-			name: "Generics",
-			short: "gen",
-			subsections: []subsection{
-				{name: "Basic", content: fmt.Sprintf(
-					("%sGeneric Function%s:\n\n"+
-						"\tfunc %sPrintSlice%s[%sT%s %sany%s](%ss%s []%sT%s) {\n"+
-						"\t\tfor _, %sv%s := range %ss%s {\n"+
-						"\t\t\t%sfmt.Print%s(%sv%s)\n"+
-						"\t\t}\n"+
-						"\t}"),
-					BoldItalic, Reset, // Generic Function
-					Cyan, Reset, Yellow, Reset, Cyan, Reset, // PrintSlice T any
-					Yellow, Reset, Yellow, Reset, // s []T
-					Yellow, Reset, Yellow, Reset, // v s
-					Cyan, Reset, Yellow, Reset, // fmt.Print v
-				)},
-				{name: "Constraints", content: fmt.Sprintf(
-					("%sType Constraints%s:\n\n"+
-						"\ttype %sNumber%s interface {\n"+
-						"\t\t%sint%s | %sfloat64%s\n"+
-						"\t}\n\n"+
-						"\tfunc %sSum%s[%sT%s %sNumber%s](%snums%s []%sT%s) %sT%s {\n"+
-						"\t\tvar total %sT%s\n"+
-						"\t\tfor _, %sn%s := range %snums%s {\n"+
-						"\t\t\ttotal += %sn%s\n"+
-						"\t\t}\n"+
-						"\t\treturn total\n"+
-						"\t}"),
-					BoldItalic, Reset, // Type Constraints
-					Yellow, Reset, // Number
-					Yellow, Reset, Yellow, Reset, // int float64
-					Cyan, Reset, Yellow, Reset, Yellow, Reset, // Sum T Number
-					Yellow, Reset, Yellow, Reset, Yellow, Reset, // nums []T
-					Yellow, Reset, // n nums
-					Yellow, Reset, Yellow, Reset, // total += n
-					Yellow, Reset, // total
-				)},
-				{name: "GenericStruct", content: fmt.Sprintf(
-					("%sGeneric Struct%s:\n\n"+
-						"\ttype %sContainer%s[%sT%s %sany%s] struct {\n"+
-						"\t\tValue %sT%s\n"+
-						"\t}\n\n"+
-						"\tfunc (%sc%s *%sContainer%s[%sT%s]) %sGet%s() %sT%s {\n"+
-						"\t\treturn %sc%s.Value\n"+
-						"\t}"),
-					BoldItalic, Reset, // Generic Struct
-					Yellow, Reset, Yellow, Reset, Cyan, Reset, // Container T any
-					Yellow, Reset, Yellow, Reset, // Value T
-					Yellow, Reset, Yellow, Reset, // c Container
-					Cyan, Reset, Yellow, Reset, // Get T
-					Yellow, Reset, // c Value
-				)},
-			},
-		},
+// fatal prints the warnings reported so far and err to stderr, and exits
+// with the status for err's kind. They are styled only when stderr should
+// be coloured. With --format json they are written as one JSON document
+// instead.
+func fatal(mode string, err error) {
+	warnings := takeDiagnostics()
+	styled := colorEnabled(mode, os.Stderr)
+	message := describeError(err, styled)
+	if outputFormat == formatJSON {
+		message = formatErrorJSON(err, warnings)
+	} else if output := formatDiagnostics(warnings, styled); output != "" {
+		message = output + "\n" + message
 	}
-	return sections
-}
\ No newline at end of file
+	fmt.Fprintln(os.Stderr, message)
+	os.Exit(exitStatus(err))
+}
+
+// writeWarnings prints the warnings reported so far to stderr, styled only
+// when stderr should be coloured.
+func writeWarnings(mode string) {
+	writeDiagnostics(os.Stderr, colorEnabled(mode, os.Stderr))
+}
@@ -2,112 +2,36 @@ package main
 
 import (
 	"testing"
-	"os"
-	"reflect"
 	"strings"
-)
-
-// Parse Command
-func TestParseCommand(t *testing.T) {
-	// Save original command-line arguments
-	origArgs := os.Args
-	defer func() { os.Args = origArgs }()
-
-	tests := []struct {
-		name        string
-		args        []string
-		wantCmd     command
-		wantErr     bool
-		errContains string
-	}{
-		{
-			name:        "no arguments",
-			args:        []string{"gosyn"},
-			wantCmd:     command{},
-			wantErr:     true,
-			errContains: "no command provided",
-		},
-		{
-			name:    "action only",
-			args:    []string{"gosyn", "help"},
-			wantCmd: command{action: "help", args: []string{""}},
-			wantErr: false,
-		},
-		{
-			name:    "action with one argument",
-			args:    []string{"gosyn", "listSections", "variables"},
-			wantCmd: command{action: "listSections", args: []string{"variables"}},
-			wantErr: false,
-		},
-		{
-			name:    "action with multiple arguments",
-			args:    []string{"gosyn", "tax", "functions", "closures"},
-			wantCmd: command{action: "tax", args: []string{"functions", "closures"}},
-			wantErr: false,
-		},
-		{
-			name:    "action with empty arguments",
-			args:    []string{"gosyn", "update", "", "test"},
-			wantCmd: command{action: "update", args: []string{"test"}},
-			wantErr: false,
-		},
-	}
 
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			// Set test arguments
-			os.Args = tt.args
-
-			gotCmd, err := parseCommand()
-
-			if (err != nil) != tt.wantErr {
-				t.Fatalf("parseCommand() error = %v, wantErr %v", err, tt.wantErr)
-			}
-
-			if err != nil {
-				if tt.errContains != "" && !strings.Contains(err.Error(), tt.errContains) {
-					t.Errorf("parseCommand() error message %q should contain %q", 
-						err.Error(), tt.errContains)
-				}
-				return
-			}
-
-			if !reflect.DeepEqual(gotCmd, tt.wantCmd) {
-				t.Errorf("parseCommand() = %+v, want %+v", gotCmd, tt.wantCmd)
-			}
-		})
-	}
-}
+	"github.com/bbarrington0099/gosyn/reference"
+)
 
 // Execute Command
 func TestExecuteCommand(t *testing.T) {
-    // Backup and restore original command-line arguments
-    origArgs := os.Args
-    defer func() { os.Args = origArgs }()
-
     // Backup original function and restore when done
     oldInit := initializeSectionsFn
     defer func() { initializeSectionsFn = oldInit }()
 
     // Mock sections data
-    testSections := []section{
+    testSections := []reference.Section{
         {
-            name: "Variables",
-            subsections: []subsection{
-                {name: "Declaration", content: "var x int"},
-                {name: "Types", content: "int, string"},
+            Name: "Variables",
+            Subsections: []reference.Subsection{
+                {Name: "Declaration", Content: "var x int"},
+                {Name: "Types", Content: "int, string"},
             },
         },
         {
-            name: "Functions",
-            subsections: []subsection{
-                {name: "Declaration", content: "func f() {}"},
+            Name: "Functions",
+            Subsections: []reference.Subsection{
+                {Name: "Declaration", Content: "func f() {}"},
             },
         },
     }
 
     // Set our mock implementation
-    initializeSectionsFn = func() []section { return testSections }
+    initializeSectionsFn = func() []reference.Section { return testSections }
 
     tests := []struct {
 		name        string
@@ -170,6 +94,40 @@ func TestExecuteCommand(t *testing.T) {
 			errContains: "section \"Invalid\" not found",
 		},
 
+		// Search commands
+		{
+			name:        "search missing query",
+			args:        []string{"gosyn", "search"},
+			wantErr:     true,
+			errContains: "no query provided",
+		},
+		{
+			name:       "search alias",
+			args:       []string{"gosyn", "s", "func"},
+			wantOutput: func() string {
+				results, err := searchSections(testSections, "func", false)
+				if err != nil {
+					t.Fatalf("searchSections() error = %v", err)
+				}
+				return formatSearchResults("func", results)
+			}(),
+			wantErr:    false,
+		},
+
+		// Completion commands
+		{
+			name:        "completion missing shell",
+			args:        []string{"gosyn", "completion"},
+			wantErr:     true,
+			errContains: "no shell provided",
+		},
+		{
+			name:       "completion bash",
+			args:       []string{"gosyn", "completion", "bash"},
+			wantOutput: completionScripts["bash"],
+			wantErr:    false,
+		},
+
 		// Tax commands
 		{
 			name:       "tax valid",
@@ -184,10 +142,16 @@ func TestExecuteCommand(t *testing.T) {
 			wantErr:    false,
 		},
 		{
-			name:        "tax missing subsection",
-			args:        []string{"gosyn", "Variables"},
-			wantErr:     true,
-			errContains: "no subsection name provided",
+			name:       "tax section only",
+			args:       []string{"gosyn", "Variables"},
+			wantOutput: taxSection(testSections[0]),
+			wantErr:    false,
+		},
+		{
+			name:       "tax section all",
+			args:       []string{"gosyn", "Variables", "ALL"},
+			wantOutput: taxSection(testSections[0]),
+			wantErr:    false,
 		},
 		{
 			name:        "tax invalid section with subsection",
@@ -196,6 +160,26 @@ func TestExecuteCommand(t *testing.T) {
 			errContains: "section \"Invalid\" not found",
 		},
 
+		// Lone subsection lookups
+		{
+			name:       "lone unique subsection",
+			args:       []string{"gosyn", "Types"},
+			wantOutput: func() string {
+				output, err := tax(testSections, "Variables", "Types")
+				if err != nil {
+					t.Fatalf("tax() error = %v", err)
+				}
+				return output
+			}(),
+			wantErr:    false,
+		},
+		{
+			name:       "lone subsection in several sections",
+			args:       []string{"gosyn", "declaration"},
+			wantOutput: listSubsectionCandidates(testSections, "declaration", []reference.Ref{{Section: 0, Subsection: 0}, {Section: 1, Subsection: 0}}),
+			wantErr:    false,
+		},
+
 		// Edge cases
 		{
 			name:        "unknown section or command",
@@ -213,28 +197,57 @@ func TestExecuteCommand(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			os.Args = tt.args
-			got, err := executeCommand()
+			got, err := runArgs(initializeSectionsFn(), tt.args[1:])
 
 			if (err != nil) != tt.wantErr {
-				t.Fatalf("executeCommand() error = %v, wantErr %v", err, tt.wantErr)
+				t.Fatalf("runArgs() error = %v, wantErr %v", err, tt.wantErr)
 			}
 
 			if err != nil {
 				if tt.errContains != "" && !strings.Contains(err.Error(), tt.errContains) {
-					t.Errorf("executeCommand() error = %v, want contains %q", 
+					t.Errorf("runArgs() error = %v, want contains %q", 
 						err.Error(), tt.errContains)
 				}
-				// Verify ANSI codes are present in errors
-				if tt.wantErr && !strings.Contains(err.Error(), BoldRed) {
-					t.Error("Error message should contain red formatting")
+				// Errors are plain; only their printed prefix is styled
+				if strings.Contains(err.Error(), "\033[") {
+					t.Errorf("Error message %q should not contain ANSI codes", err.Error())
+				}
+				if !strings.HasPrefix(describeError(err, true), style(roleError)+"ERROR") {
+					t.Error("Described error should start with a red ERROR")
 				}
 				return
 			}
 
 			if got != tt.wantOutput {
-				t.Errorf("executeCommand() = %q, want %q", got, tt.wantOutput)
+				t.Errorf("runArgs() = %q, want %q", got, tt.wantOutput)
 			}
 		})
 	}
-}
\ No newline at end of file
+}
+// Tax Section
+func TestTaxSection(t *testing.T) {
+	sec := reference.Section{
+		Name: "Variables",
+		Subsections: []reference.Subsection{
+			{Name: "Declaration", Content: "{title:Declaration}"},
+			{Name: "Types", Content: "{title:Types}"},
+		},
+	}
+
+	got := taxSection(sec)
+	first := strings.Index(got, style(roleHeading)+"Declaration"+Reset)
+	second := strings.Index(got, style(roleHeading)+"Types"+Reset)
+	if first < 0 || second < first {
+		t.Errorf("taxSection() = %q, want every subsection heading in order", got)
+	}
+	if !strings.Contains(got, style(roleTitle)+"Types"+Reset) {
+		t.Errorf("taxSection() = %q, want rendered subsection content", got)
+	}
+
+	// A subsection actually named "all" is still reachable
+	sec.Subsections = append(sec.Subsections, reference.Subsection{Name: "All", Content: "only this"})
+	output, err := tax([]reference.Section{sec}, "Variables", "all")
+	if err != nil || !strings.Contains(stripANSI(output), "only this") || strings.Contains(output, "Declaration") {
+		t.Errorf("tax() with a subsection named All = %q, %v", output, err)
+	}
+}
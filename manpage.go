@@ -0,0 +1,142 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/bbarrington0099/gosyn/reference"
+)
+
+// writeManPages writes gosyn(1) and gosyn-syntax(7) under dir's man1 and
+// man7, the layout man expects of a directory on its MANPATH, returning the
+// paths written.
+func writeManPages(sections []reference.Section, dir string) ([]string, error) {
+	pages := []struct {
+		path    string
+		content string
+	}{
+		{filepath.Join(dir, "man1", "gosyn.1"), commandsManPage()},
+		{filepath.Join(dir, "man7", "gosyn-syntax.7"), syntaxManPage(sections)},
+	}
+	var paths []string
+	for _, page := range pages {
+		err := os.MkdirAll(filepath.Dir(page.path), 0o755)
+		if err == nil {
+			err = os.WriteFile(page.path, []byte(page.content), 0o644)
+		}
+		if err != nil {
+			return nil, withKind(ErrWrite, fmt.Errorf("cannot write the man pages: %w", err))
+		}
+		paths = append(paths, page.path)
+	}
+	return paths, nil
+}
+
+// manSummary reports what writeManPages wrote.
+func manSummary(paths []string) string {
+	output := fmt.Sprintf("%sWrote man pages%s:\n", style(roleHeading), Reset)
+	for _, path := range paths {
+		output += fmt.Sprintf(" - %s%s%s\n", style(roleArg), path, Reset)
+	}
+	return strings.TrimSuffix(output, "\n")
+}
+
+// commandsManPage renders gosyn(1) from commandDocs and globalFlags.
+func commandsManPage() string {
+	var out strings.Builder
+	out.WriteString(".TH GOSYN 1 \"\" \"gosyn\" \"User Commands\"\n")
+	out.WriteString(".SH NAME\ngosyn \\- Go syntax quick reference\n")
+	out.WriteString(".SH SYNOPSIS\n.B gosyn\n[\\fIoptions\\fR] [\\fIcommand\\fR] [\\fIarguments\\fR]\n")
+	out.WriteString(".SH DESCRIPTION\n")
+	out.WriteString("gosyn prints Go syntax snippets from the terminal. Sections group related subsections, and names may be abbreviated to a unique prefix or subsequence. Run bare on a terminal, it opens an interactive prompt.\n")
+	out.WriteString(".SH COMMANDS\n")
+	for _, doc := range commandDocs {
+		fmt.Fprintf(&out, ".TP\n\\fB%s\\fR\n%s\n", roffEscape(doc.invocation()), roffDoc(doc.summary))
+		for _, option := range doc.options() {
+			fmt.Fprintf(&out, ".RS\n.TP\n\\fI%s\\fR\n%s\n.RE\n", roffEscape(option.name), roffDoc(option.desc))
+		}
+	}
+	out.WriteString(".SH OPTIONS\n")
+	for _, option := range flagOptions(globalFlags) {
+		fmt.Fprintf(&out, ".TP\n\\fB%s\\fR\n%s\n", roffEscape(option.name), roffDoc(option.desc))
+	}
+	out.WriteString(".SH EXIT STATUS\n")
+	fmt.Fprintf(&out, ".TP\n\\fB0\\fR\nsuccess\n.TP\n\\fB%d\\fR\nany other error\n", exitInternal)
+	for _, k := range errorKinds {
+		fmt.Fprintf(&out, ".TP\n\\fB%d\\fR\n%s\n", k.status, roffEscape(k.desc))
+	}
+	out.WriteString(".SH FILES\n")
+	for _, file := range []optionDoc{
+		{"$XDG_CONFIG_HOME/gosyn/config", "\"key: value\" settings; {arg:theme} names the default theme"},
+		{"$XDG_CONFIG_HOME/gosyn/sections/*.md", "user-defined sections, loaded next to the built-in ones"},
+		{"$XDG_CONFIG_HOME/gosyn/history", "history of the interactive prompt"},
+	} {
+		fmt.Fprintf(&out, ".TP\n\\fI%s\\fR\n%s\n", roffEscape(file.name), roffDoc(file.desc))
+	}
+	out.WriteString(".SH SEE ALSO\n.BR gosyn-syntax (7)\n")
+	return out.String()
+}
+
+// syntaxManPage renders gosyn-syntax(7) with every section and subsection.
+func syntaxManPage(sections []reference.Section) string {
+	var out strings.Builder
+	out.WriteString(".TH GOSYN-SYNTAX 7 \"\" \"gosyn\" \"Miscellaneous Information Manual\"\n")
+	out.WriteString(".SH NAME\ngosyn-syntax \\- Go syntax reference shown by gosyn\n")
+	out.WriteString(".SH DESCRIPTION\n")
+	out.WriteString("Every section and subsection \\fBgosyn\\fR(1) knows, as printed by \\fBgosyn\\fR \\fIsection\\fR \\fIsubsection\\fR.\n")
+	for _, sec := range sections {
+		fmt.Fprintf(&out, ".SH %s\n", roffEscape(strings.ToUpper(sec.Name)))
+		if sec.Short != "" {
+			fmt.Fprintf(&out, "Short name: \\fB%s\\fR\n", roffEscape(sec.Short))
+		}
+		for _, sub := range sec.Subsections {
+			content := strings.TrimRight(reference.Render(sub, roffRenderer), "\n")
+			fmt.Fprintf(&out, ".SS %s\n.PP\n.RS 4\n.nf\n%s\n.fi\n.RE\n", roffEscape(sub.Name), roffLines(content))
+		}
+	}
+	out.WriteString(".SH SEE ALSO\n.BR gosyn (1)\n")
+	return out.String()
+}
+
+// roffRenderer renders markup for a man page: titles, headings and
+// keywords in bold, placeholders in italics.
+func roffRenderer(role string, text string) string {
+	switch role {
+	case roleTitle, roleHeading, roleKeyword, roleFlow, roleLabel:
+		return "\\fB" + roffEscape(text) + "\\fR"
+	case rolePlaceholder:
+		return "\\fI" + roffEscape(text) + "\\fR"
+	default:
+		return roffEscape(text)
+	}
+}
+
+// roffDoc renders doc markup for a man page, commands in bold and
+// arguments in italics.
+func roffDoc(s string) string {
+	return roffLines(renderDoc(s, roffEscape, func(role string, text string) string {
+		if role == roleCommand {
+			return "\\fB" + roffEscape(text) + "\\fR"
+		}
+		return "\\fI" + roffEscape(text) + "\\fR"
+	}))
+}
+
+// roffEscape escapes backslashes and hyphens so roff prints them as typed.
+func roffEscape(s string) string {
+	return strings.NewReplacer("\\", "\\e", "-", "\\-").Replace(s)
+}
+
+// roffLines keeps lines starting with "." or "'" from being read as roff
+// requests.
+func roffLines(s string) string {
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		if strings.HasPrefix(line, ".") || strings.HasPrefix(line, "'") {
+			lines[i] = "\\&" + line
+		}
+	}
+	return strings.Join(lines, "\n")
+}
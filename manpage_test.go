@@ -0,0 +1,68 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/bbarrington0099/gosyn/reference"
+)
+
+// Commands Man Page
+func TestCommandsManPage(t *testing.T) {
+	page := commandsManPage()
+	if !strings.HasPrefix(page, ".TH GOSYN 1 ") {
+		t.Errorf("commandsManPage() should start with .TH GOSYN 1, got %q", page[:min(len(page), 40)])
+	}
+	for _, doc := range commandDocs {
+		if !strings.Contains(page, "\\fB"+roffEscape(doc.invocation())+"\\fR\n"+roffDoc(doc.summary)+"\n") {
+			t.Errorf("commandsManPage() is missing command %q", doc.invocation())
+		}
+	}
+	for _, option := range flagOptions(globalFlags) {
+		if !strings.Contains(page, roffEscape(option.name)) {
+			t.Errorf("commandsManPage() is missing option %q", option.name)
+		}
+	}
+	for _, k := range errorKinds {
+		if !strings.Contains(page, fmt.Sprintf("\\fB%d\\fR\n%s\n", k.status, roffEscape(k.desc))) {
+			t.Errorf("commandsManPage() is missing exit status %d", k.status)
+		}
+	}
+	for _, want := range []string{"treats \\fI<query>\\fR as", "bare \\fBgosyn\\fR on", ".BR gosyn-syntax (7)"} {
+		if !strings.Contains(page, want) {
+			t.Errorf("commandsManPage() should contain %q", want)
+		}
+	}
+}
+
+// Syntax Man Page
+func TestSyntaxManPage(t *testing.T) {
+	sections := []reference.Section{
+		{Name: "Variables", Short: "var", Subsections: []reference.Subsection{{Name: "Declaration", Content: "{title:Declaration}:\n\tvar x = 1 - 2\n.hidden\n'quoted\n\\n"}}},
+	}
+	page := syntaxManPage(sections)
+	want := ".SH VARIABLES\nShort name: \\fBvar\\fR\n.SS Declaration\n.PP\n.RS 4\n.nf\n\\fBDeclaration\\fR:\n\t\\fBvar\\fR x = 1 \\- 2\n\\&.hidden\n\\&'quoted\n\\en\n.fi\n.RE\n"
+	if !strings.Contains(page, want) {
+		t.Errorf("syntaxManPage() = %q, want it to contain %q", page, want)
+	}
+}
+
+// Write Man Pages
+func TestWriteManPages(t *testing.T) {
+	dir := t.TempDir()
+	paths, err := writeManPages([]reference.Section{{Name: "Variables"}}, dir)
+	if err != nil {
+		t.Fatalf("writeManPages() error = %v", err)
+	}
+	for i, want := range []string{filepath.Join(dir, "man1", "gosyn.1"), filepath.Join(dir, "man7", "gosyn-syntax.7")} {
+		if i >= len(paths) || paths[i] != want {
+			t.Fatalf("writeManPages() = %q, want %q at %d", paths, want, i)
+		}
+		if data, err := os.ReadFile(want); err != nil || !strings.HasPrefix(string(data), ".TH ") {
+			t.Errorf("%s was not written as a man page: %v", want, err)
+		}
+	}
+}
@@ -0,0 +1,22 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// didYouMean formats suggestions for the end of a not-found error, or returns "" when there are none.
+func didYouMean(suggestions []string) string {
+	if len(suggestions) == 0 {
+		return ""
+	}
+	return ", did you mean " + strings.Join(suggestions, " or ") + "?"
+}
+
+func styleNames(names []string, role string) []string {
+	styled := make([]string, len(names))
+	for i, name := range names {
+		styled[i] = fmt.Sprintf("%s%s%s", style(role), name, Reset)
+	}
+	return styled
+}
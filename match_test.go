@@ -0,0 +1,52 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/bbarrington0099/gosyn/reference"
+)
+
+// Tax with partial names
+func TestTaxMatching(t *testing.T) {
+	sections := []reference.Section{
+		{Name: "Conditionals", Short: "cond", Subsections: []reference.Subsection{{Name: "If"}, {Name: "IfElse"}, {Name: "Switch"}}},
+		{Name: "Concurrency", Short: "concurrent", Subsections: []reference.Subsection{{Name: "Mutex"}}},
+	}
+
+	tests := []struct {
+		name        string
+		section     string
+		subsection  string
+		wantHeader  string
+		errContains string
+	}{
+		{name: "prefix section and subsection", section: "Condit", subsection: "sw", wantHeader: "Switch"},
+		{name: "exact subsection beats prefix", section: "cond", subsection: "if", wantHeader: "If"},
+		{name: "ambiguous section", section: "con", subsection: "If", errContains: "section \"con\" is ambiguous"},
+		{name: "unique subsection prefix", section: "cond", subsection: "IfE", wantHeader: "IfElse"},
+		{name: "ambiguous subsection", section: "cond", subsection: "i", errContains: "subsection \"i\" is ambiguous in section \"Conditionals\""},
+		{name: "subsection not found", section: "cond", subsection: "Loop", errContains: "subsection \"Loop\" not found in section \"Conditionals\""},
+		{name: "subsection typo suggests", section: "cond", subsection: "Swtich", errContains: "did you mean Switch?"},
+		{name: "section typo suggests", section: "Concurency", subsection: "Mutex", wantHeader: "Mutex"},
+		{name: "section transposition suggests", section: "Cnodit", subsection: "If", errContains: "did you mean Conditionals?"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tax(sections, tt.section, tt.subsection)
+			if tt.errContains != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errContains) {
+					t.Fatalf("tax() error = %v, want contains %q", err, tt.errContains)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("tax() error = %v", err)
+			}
+			if !strings.Contains(got, style(roleSubsection)+tt.wantHeader+Reset+" in") {
+				t.Errorf("tax() = %q, want subsection %q", got, tt.wantHeader)
+			}
+		})
+	}
+}
@@ -0,0 +1,30 @@
+package main
+
+import (
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// page sends output to the user's pager, $PAGER or "less -R" when unset,
+// and returns "" once the pager exits. When no pager can be started the
+// output is returned unchanged for the caller to print instead.
+func page(output string) (string, error) {
+	pager := strings.Fields(os.Getenv("PAGER"))
+	if len(pager) == 0 {
+		pager = []string{"less", "-R"}
+	}
+	path, err := exec.LookPath(pager[0])
+	if err != nil {
+		return output, nil
+	}
+
+	cmd := exec.Command(path, pager[1:]...)
+	cmd.Stdin = strings.NewReader(output)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return output, nil
+	}
+	return "", nil
+}
@@ -0,0 +1,212 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// command is a command line read by parseCommand.
+type command struct {
+	doc     *commandDoc       // the command to run, nil when none was given
+	action  string            // the command name as typed, empty for the section lookup
+	args    []string          // positional arguments
+	flags   map[string]string // the command's own flags, by key; "true" for a boolean flag
+	globals map[string]string // global flags, by key, wherever they were given
+}
+
+// completeDoc is the hidden command completion scripts call, see
+// completeCommand. Its arguments are the raw words being completed.
+var completeDoc = commandDoc{names: []string{completeCommand}}
+
+// lookupDoc is the section lookup, run when the first word is not a
+// command.
+func lookupDoc() *commandDoc {
+	for i := range commandDocs {
+		if len(commandDocs[i].names) == 0 {
+			return &commandDocs[i]
+		}
+	}
+	panic("lookupDoc(): commandDocs has no section lookup")
+}
+
+// findCommand returns the command named or aliased name, ignoring case.
+func findCommand(name string) (*commandDoc, bool) {
+	for i, doc := range commandDocs {
+		for _, alias := range doc.names {
+			if strings.EqualFold(alias, name) {
+				return &commandDocs[i], true
+			}
+		}
+	}
+	return nil, false
+}
+
+// parseCommand reads args, the words after "gosyn". Global flags may come
+// before the command and, unless the command has a flag of the same name,
+// after it too; the command's own flags may be mixed with its arguments.
+// After "--" every word is an argument, so a leading "--" always starts
+// a section lookup. A first word that is not a command starts one too, and
+// is its first argument.
+//
+// Unknown flags, missing values and missing arguments are usage errors;
+// extra arguments are warned about and dropped. With --help nothing is
+// checked, as the command will only print its usage.
+func parseCommand(args []string) (command, error) {
+	cmd := command{flags: map[string]string{}, globals: map[string]string{}}
+	i := 0
+	for ; i < len(args) && isFlag(args[i]); i++ {
+		if args[i] == "--" {
+			if i+1 == len(args) {
+				return cmd, nil
+			}
+			cmd.doc, cmd.args = lookupDoc(), args[i+1:]
+			return cmd, cmd.checkArgs()
+		}
+		n, err := cmd.readFlag(args[i:], nil)
+		if err != nil {
+			return cmd, err
+		}
+		i += n - 1
+	}
+	if i == len(args) {
+		return cmd, nil
+	}
+
+	if strings.EqualFold(args[i], completeCommand) {
+		cmd.doc, cmd.action, cmd.args = &completeDoc, completeCommand, args[i+1:]
+		return cmd, nil
+	}
+	var positional []string
+	if doc, ok := findCommand(args[i]); ok {
+		cmd.doc, cmd.action = doc, args[i]
+	} else {
+		cmd.doc = lookupDoc()
+		positional = append(positional, args[i])
+	}
+	for i++; i < len(args); i++ {
+		switch arg := args[i]; {
+		case arg == "--":
+			positional = append(positional, args[i+1:]...)
+			i = len(args)
+		case isFlag(arg):
+			n, err := cmd.readFlag(args[i:], cmd.doc.flags)
+			if err != nil {
+				return cmd, err
+			}
+			i += n - 1
+		default:
+			positional = append(positional, arg)
+		}
+	}
+	cmd.args = positional
+	if cmd.help() {
+		return cmd, nil
+	}
+	return cmd, cmd.checkArgs()
+}
+
+// isFlag reports whether arg is a flag or "--"; a lone "-" is an argument.
+func isFlag(arg string) bool {
+	return len(arg) > 1 && arg[0] == '-'
+}
+
+// readFlag reads the flag starting args, taking its value from the next
+// word unless given as "--flag=value", and returns how many words it
+// used. own are the command's flags, looked up before the global ones.
+func (cmd *command) readFlag(args []string, own []flagDoc) (int, error) {
+	name, value, hasValue := strings.Cut(args[0], "=")
+	flag, values, ok := cmd.findFlag(name, own)
+	if !ok {
+		helpCommand := "gosyn"
+		if cmd.doc != nil {
+			helpCommand = strings.TrimSpace(helpCommand + " " + cmd.doc.name())
+		}
+		return 0, withKind(ErrUsage, fmt.Errorf("unknown flag \"%s\"%s, see \"%s --help\"", name, cmd.forCommand(), helpCommand))
+	}
+	n := 1
+	switch {
+	case flag.value == "" && hasValue:
+		return 0, withKind(ErrUsage, fmt.Errorf("flag \"%s\"%s does not take a value", name, cmd.forCommand()))
+	case flag.value == "":
+		value = "true"
+	case !hasValue && len(args) < 2:
+		return 0, withKind(ErrUsage, fmt.Errorf("no value provided for %s%s", flag.invocation(), cmd.forCommand()))
+	case !hasValue:
+		value = args[1]
+		n = 2
+	}
+	values[flag.key()] = value
+	return n, nil
+}
+
+// findFlag looks name up in own, then in globalFlags, returning the map
+// its value belongs in.
+func (cmd *command) findFlag(name string, own []flagDoc) (flagDoc, map[string]string, bool) {
+	for _, flags := range []struct {
+		docs   []flagDoc
+		values map[string]string
+	}{{own, cmd.flags}, {globalFlags, cmd.globals}} {
+		for _, flag := range flags.docs {
+			for _, spelling := range flag.names {
+				if spelling == name {
+					return flag, flags.values, true
+				}
+			}
+		}
+	}
+	return flagDoc{}, nil, false
+}
+
+// checkArgs checks the arguments and flags against cmd.doc, joining the
+// words of a rest argument into one.
+func (cmd *command) checkArgs() error {
+	for k, arg := range cmd.doc.args {
+		if arg.rest && k < len(cmd.args) {
+			cmd.args = append(cmd.args[:k], strings.Join(cmd.args[k:], " "))
+		}
+		if k >= len(cmd.args) && !arg.optional {
+			return withKind(ErrUsage, fmt.Errorf("no %s provided for %s", arg.what, cmd.doc.invocation()))
+		}
+	}
+	for _, flag := range cmd.doc.flags {
+		if _, ok := cmd.flags[flag.key()]; flag.required && !ok {
+			return withKind(ErrUsage, fmt.Errorf("no %s provided for %s", flag.invocation(), cmd.doc.invocation()))
+		}
+	}
+	if extra := len(cmd.args) - len(cmd.doc.args); extra > 0 {
+		warn(warnExtraArguments, "too many arguments provided%s, following Args ignored: %v", cmd.forCommand(), cmd.args[len(cmd.doc.args):])
+		cmd.args = cmd.args[:len(cmd.doc.args)]
+	}
+	return nil
+}
+
+// forCommand names the command in messages: " for search command", or
+// nothing before one is known.
+func (cmd *command) forCommand() string {
+	switch {
+	case cmd.doc == nil:
+		return ""
+	case cmd.doc.name() == "":
+		return " for section lookup"
+	default:
+		return " for " + cmd.doc.name() + " command"
+	}
+}
+
+// help reports whether --help was given.
+func (cmd command) help() bool {
+	return cmd.globals[helpFlag.key()] != ""
+}
+
+// commandHelp shows how to use one command, for --help.
+func commandHelp(doc commandDoc) string {
+	output := fmt.Sprintf("%sUsage%s: %s%s%s\n%s\n",
+		style(roleHeading), Reset, // Usage
+		style(roleCommand), strings.TrimSpace("gosyn "+doc.invocation()), Reset, // gosyn <command> <usage>
+		terminalDoc(doc.summary),
+	)
+	for _, option := range doc.options() {
+		output += fmt.Sprintf(" - %s%s%s %s\n", style(roleArg), option.name, Reset, terminalDoc(option.desc))
+	}
+	return output
+}
@@ -0,0 +1,117 @@
+package main
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/bbarrington0099/gosyn/reference"
+)
+
+// runArgs parses and runs args, the words after "gosyn", as main does.
+func runArgs(sections []reference.Section, args []string) (string, error) {
+	cmd, err := parseCommand(args)
+	if err != nil {
+		return "", err
+	}
+	return runCommand(sections, cmd)
+}
+
+// Parse Command
+func TestParseCommand(t *testing.T) {
+	defer takeDiagnostics()
+
+	tests := []struct {
+		name        string
+		args        []string
+		wantCommand string
+		wantArgs    []string
+		wantFlags   map[string]string
+		wantGlobals map[string]string
+	}{
+		{"no arguments", nil, "", nil, map[string]string{}, map[string]string{}},
+		{"command only", []string{"help"}, "help", nil, map[string]string{}, map[string]string{}},
+		{"alias", []string{"lsub", "var"}, "listSubsections", []string{"var"}, map[string]string{}, map[string]string{}},
+		{"section lookup", []string{"Variables", "Types"}, "", []string{"Variables", "Types"}, map[string]string{}, map[string]string{}},
+		{"global flag before", []string{"--color", "never", "lsec"}, "listSections", nil, map[string]string{}, map[string]string{"--color": "never"}},
+		{"global flag after", []string{"lsec", "--theme=light"}, "listSections", nil, map[string]string{}, map[string]string{"--theme": "light"}},
+		{"own flag shadows global", []string{"--format", "json", "export", "--format", "html", "--out", "docs"}, "export", nil, map[string]string{"--format": "html", "--out": "docs"}, map[string]string{"--format": "json"}},
+		{"boolean flag", []string{"Variables", "-p"}, "", []string{"Variables"}, map[string]string{"--pager": "true"}, map[string]string{}},
+		{"rest argument joined", []string{"s", "-r", "for", "range"}, "search", []string{"for range"}, map[string]string{"--regex": "true"}, map[string]string{}},
+		{"double dash ends flags", []string{"s", "--", "-r"}, "search", []string{"-r"}, map[string]string{}, map[string]string{}},
+		{"leading double dash looks up a section", []string{"--color", "never", "--", "help", "--pager"}, "", []string{"help", "--pager"}, map[string]string{}, map[string]string{"--color": "never"}},
+		{"lone dash is an argument", []string{"s", "-"}, "search", []string{"-"}, map[string]string{}, map[string]string{}},
+		{"empty argument kept", []string{"Variables", ""}, "", []string{"Variables", ""}, map[string]string{}, map[string]string{}},
+		{"extra arguments dropped", []string{"lsub", "var", "extra"}, "listSubsections", []string{"var"}, map[string]string{}, map[string]string{}},
+		{"help skips checks", []string{"export", "--help"}, "export", nil, map[string]string{}, map[string]string{"--help": "true"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd, err := parseCommand(tt.args)
+			if err != nil {
+				t.Fatalf("parseCommand(%q) error = %v", tt.args, err)
+			}
+			name := ""
+			if cmd.doc != nil {
+				name = cmd.doc.name()
+			}
+			if name != tt.wantCommand || !reflect.DeepEqual(cmd.args, tt.wantArgs) || !reflect.DeepEqual(cmd.flags, tt.wantFlags) || !reflect.DeepEqual(cmd.globals, tt.wantGlobals) {
+				t.Errorf("parseCommand(%q) = %q %q %v %v, want %q %q %v %v", tt.args, name, cmd.args, cmd.flags, cmd.globals, tt.wantCommand, tt.wantArgs, tt.wantFlags, tt.wantGlobals)
+			}
+		})
+	}
+
+	// The first word of an unknown command is the section to look up
+	cmd, err := parseCommand([]string{"unknown"})
+	if err != nil || cmd.doc != lookupDoc() || !reflect.DeepEqual(cmd.args, []string{"unknown"}) {
+		t.Errorf("parseCommand(unknown) = %+v, %v, want a section lookup", cmd, err)
+	}
+}
+
+// Parse Command Errors
+func TestParseCommandErrors(t *testing.T) {
+	tests := []struct {
+		name        string
+		args        []string
+		errContains string
+	}{
+		{"unknown flag", []string{"lsec", "--nope"}, `unknown flag "--nope" for listSections command`},
+		{"unknown global flag", []string{"--nope"}, `unknown flag "--nope"`},
+		{"flag of another command", []string{"Variables", "--regex"}, `unknown flag "--regex" for section lookup`},
+		{"missing value", []string{"lsec", "--color"}, "no value provided for --color <auto | always | never>"},
+		{"value on a boolean flag", []string{"s", "--regex=yes", "x"}, `flag "--regex" for search command does not take a value`},
+		{"missing argument", []string{"lsub"}, "no section name provided for (listSubsections | lsub) <sectionName>"},
+		{"missing required flag", []string{"export"}, "no --out <dir> provided for export"},
+		{"missing required flag for gen-man", []string{"gen-man", "--format", "man"}, "no --out <dir> provided for gen-man"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseCommand(tt.args)
+			if err == nil || !strings.Contains(err.Error(), tt.errContains) {
+				t.Fatalf("parseCommand(%q) error = %v, want contains %q", tt.args, err, tt.errContains)
+			}
+			if !errors.Is(err, ErrUsage) {
+				t.Errorf("parseCommand(%q) error = %v, want a usage error", tt.args, err)
+			}
+		})
+	}
+}
+
+// Command Help
+func TestCommandHelp(t *testing.T) {
+	doc, _ := findCommand("export")
+	got := stripANSI(commandHelp(*doc))
+	for _, want := range []string{"Usage: gosyn export [--format <markdown | html>] --out <dir>\n", "\n - --out <dir> "} {
+		if !strings.Contains(got, want) {
+			t.Errorf("commandHelp(export) = %q, want contains %q", got, want)
+		}
+	}
+
+	output, err := runArgs(nil, []string{"s", "-h"})
+	if err != nil || !strings.HasPrefix(stripANSI(output), "Usage: gosyn (search | s) <query> [-r | --regex]") {
+		t.Errorf("runArgs(s -h) = %q, %v, want the search usage", output, err)
+	}
+}
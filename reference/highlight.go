@@ -0,0 +1,113 @@
+package reference
+
+import (
+	"go/scanner"
+	"go/token"
+	"go/types"
+	"regexp"
+)
+
+// placeholderPattern matches template placeholders such as <collection>,
+// which are not Go and are highlighted as their own class.
+var placeholderPattern = regexp.MustCompile(`<[A-Za-z_][A-Za-z0-9_]*>`)
+
+// Highlight runs HighlightGo over every plain span, leaving spans that
+// already carry a role as written.
+func Highlight(spans []Span) []Span {
+	var out []Span
+	for _, span := range spans {
+		if span.Role != "" {
+			out = append(out, span)
+			continue
+		}
+		out = append(out, HighlightGo(span.Text)...)
+	}
+	return out
+}
+
+// HighlightGo tokenizes src with go/scanner and splits it into spans tagged
+// with the role of each token. Text between tokens, delimiters and anything
+// the scanner cannot make sense of are kept as plain spans, so the spans
+// always join back into src.
+func HighlightGo(src string) []Span {
+	roles := make([]string, len(src))
+
+	// Placeholders are blanked out before scanning so that "<collection>"
+	// is not read as "<", "collection", ">", and are marked after the
+	// tokens so they stand out even inside a string or comment.
+	placeholders := placeholderPattern.FindAllStringIndex(src, -1)
+	masked := []byte(src)
+	for _, loc := range placeholders {
+		for i := loc[0]; i < loc[1]; i++ {
+			masked[i] = ' '
+		}
+	}
+
+	fset := token.NewFileSet()
+	file := fset.AddFile("", fset.Base(), len(masked))
+	var s scanner.Scanner
+	s.Init(file, masked, func(token.Position, string) {}, scanner.ScanComments)
+	for {
+		pos, tok, lit := s.Scan()
+		if tok == token.EOF {
+			break
+		}
+		role := tokenRole(tok, lit)
+		if role == "" {
+			continue
+		}
+		start := file.Offset(pos)
+		end := start + len(lit)
+		if lit == "" {
+			end = start + len(tok.String())
+		}
+		if end > len(masked) || (lit != "" && string(masked[start:end]) != lit) {
+			// The scanner normalises some literals (e.g. drops '\r' from
+			// comments); leave those uncoloured rather than misplace them.
+			continue
+		}
+		for i := start; i < end; i++ {
+			roles[i] = role
+		}
+	}
+	for _, loc := range placeholders {
+		for i := loc[0]; i < loc[1]; i++ {
+			roles[i] = RolePlaceholder
+		}
+	}
+
+	var spans []Span
+	for start := 0; start < len(src); {
+		end := start + 1
+		for end < len(src) && roles[end] == roles[start] {
+			end++
+		}
+		spans = append(spans, Span{Role: roles[start], Text: src[start:end]})
+		start = end
+	}
+	return spans
+}
+
+func tokenRole(tok token.Token, lit string) string {
+	switch {
+	case tok == token.IDENT:
+		if types.Universe.Lookup(lit) != nil {
+			return RoleBuiltin
+		}
+		return RoleIdent
+	case tok.IsKeyword():
+		return RoleKeyword
+	case tok.IsLiteral():
+		return RoleLiteral
+	case tok == token.COMMENT:
+		return RoleNote
+	case tok.IsOperator():
+		switch tok {
+		case token.LPAREN, token.RPAREN, token.LBRACK, token.RBRACK, token.LBRACE, token.RBRACE,
+			token.COMMA, token.PERIOD, token.SEMICOLON, token.COLON:
+			return ""
+		}
+		return RoleOperator
+	}
+	return ""
+}
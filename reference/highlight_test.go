@@ -0,0 +1,99 @@
+package reference
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// Highlight Go
+func TestHighlightGo(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		want []Span
+	}{
+		{
+			name: "keywords identifiers and literals",
+			src:  `for i := 0; i < n; i++ {`,
+			want: []Span{
+				{Role: RoleKeyword, Text: "for"},
+				{Text: " "},
+				{Role: RoleIdent, Text: "i"},
+				{Text: " "},
+				{Role: RoleOperator, Text: ":="},
+				{Text: " "},
+				{Role: RoleLiteral, Text: "0"},
+				{Text: "; "},
+				{Role: RoleIdent, Text: "i"},
+				{Text: " "},
+				{Role: RoleOperator, Text: "<"},
+				{Text: " "},
+				{Role: RoleIdent, Text: "n"},
+				{Text: "; "},
+				{Role: RoleIdent, Text: "i"},
+				{Role: RoleOperator, Text: "++"},
+				{Text: " {"},
+			},
+		},
+		{
+			name: "placeholders and builtins",
+			src:  `x := make([]<type>, <length>)`,
+			want: []Span{
+				{Role: RoleIdent, Text: "x"},
+				{Text: " "},
+				{Role: RoleOperator, Text: ":="},
+				{Text: " "},
+				{Role: RoleBuiltin, Text: "make"},
+				{Text: "([]"},
+				{Role: RolePlaceholder, Text: "<type>"},
+				{Text: ", "},
+				{Role: RolePlaceholder, Text: "<length>"},
+				{Text: ")"},
+			},
+		},
+		{
+			name: "comments and placeholders inside strings",
+			src:  "`<tag>` // note",
+			want: []Span{
+				{Role: RoleLiteral, Text: "`"},
+				{Role: RolePlaceholder, Text: "<tag>"},
+				{Role: RoleLiteral, Text: "`"},
+				{Text: " "},
+				{Role: RoleNote, Text: "// note"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := HighlightGo(tt.src); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("HighlightGo(%q) = %+v, want %+v", tt.src, got, tt.want)
+			}
+		})
+	}
+}
+
+// Highlighting never changes the text of a snippet
+func TestHighlightGoPreservesText(t *testing.T) {
+	sections, err := Builtin()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, sec := range sections {
+		for _, sub := range sec.Subsections {
+			for _, span := range ParseMarkup(sub.Content) {
+				if span.Role != "" {
+					continue
+				}
+				var joined strings.Builder
+				for _, h := range HighlightGo(span.Text) {
+					joined.WriteString(h.Text)
+				}
+				if joined.String() != span.Text {
+					t.Errorf("%s/%s: HighlightGo() = %q, want %q", sec.Name, sub.Name, joined.String(), span.Text)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,215 @@
+package reference
+
+import (
+	"bufio"
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Section content lives in one Markdown-like file per section under
+// sections/. Files are loaded in name order, so the numeric prefix decides
+// the order sections are listed in. Files in the same format elsewhere,
+// such as gosyn's user sections directory, can be merged in on top with
+// Load and Merge.
+//
+//	# <SectionName>
+//	short: <shortName>
+//
+//	## <SubsectionName>
+//	<key>: <value>
+//
+//	<content, written in the markup described in markup.go>
+//
+// "key: value" lines directly under a subsection heading set its metadata:
+//
+//	lang: go|sh|gomod|text  language of the snippet, "go" when omitted; only
+//	                        Go snippets are syntax highlighted
+//	kind: code|template     whether a Go snippet is real code, complete
+//	                        enough to compile, or a template with
+//	                        <placeholders>; built-in Go snippets must say
+//	minGo: <version>        first Go release the content applies to, such
+//	                        as 1.18, when older ones lack what it shows
+//	maxGo: <version>        last Go release the content applies to, when
+//	                        newer ones changed what it shows
+//
+//go:embed sections/*.md
+var sectionFiles embed.FS
+
+var metaLinePattern = regexp.MustCompile(`^[a-zA-Z]+:`)
+
+// Builtin returns the sections embedded in gosyn.
+func Builtin() ([]Section, error) {
+	return Load(sectionFiles, "sections", "")
+}
+
+// Load parses every .md file in dir. A file that fails to parse is
+// reported in the returned error without stopping the others from loading.
+// When origin is not empty, every section and subsection loaded is marked
+// as coming from the file under origin it was read from.
+func Load(fsys fs.FS, dir string, origin string) ([]Section, error) {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Name() < entries[j].Name() })
+
+	var sections []Section
+	var errs []error
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".md") {
+			continue
+		}
+		name := path.Join(dir, entry.Name())
+		data, err := fs.ReadFile(fsys, name)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if origin != "" {
+			name = filepath.Join(origin, entry.Name())
+		}
+		sec, err := Parse(name, string(data))
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if origin != "" {
+			sec.Origin = name
+			for i := range sec.Subsections {
+				sec.Subsections[i].Origin = name
+			}
+		}
+		sections = append(sections, sec)
+	}
+	return sections, errors.Join(errs...)
+}
+
+// Merge adds user sections to the built-in ones. A user section
+// whose name or short name matches a built-in section extends it: its
+// subsections replace built-in subsections of the same name and are
+// appended otherwise. Any other user section is appended as a new section.
+func Merge(builtin []Section, user []Section) []Section {
+	merged := append([]Section(nil), builtin...)
+	for _, usec := range user {
+		i := Find(merged, usec.Name)
+		if i < 0 && usec.Short != "" {
+			i = Find(merged, usec.Short)
+		}
+		if i < 0 {
+			merged = append(merged, usec)
+			continue
+		}
+
+		subs := append([]Subsection(nil), merged[i].Subsections...)
+		for _, usub := range usec.Subsections {
+			replaced := false
+			for j := range subs {
+				if strings.EqualFold(subs[j].Name, usub.Name) {
+					subs[j] = usub
+					replaced = true
+					break
+				}
+			}
+			if !replaced {
+				subs = append(subs, usub)
+			}
+		}
+		merged[i].Subsections = subs
+	}
+	return merged
+}
+
+// Find returns the index of the section whose name or short name
+// matches name, ignoring case, or -1.
+func Find(sections []Section, name string) int {
+	for i, sec := range sections {
+		if strings.EqualFold(sec.Name, name) || strings.EqualFold(sec.Short, name) {
+			return i
+		}
+	}
+	return -1
+}
+
+// Parse reads one section file, path naming it in errors.
+func Parse(path string, data string) (Section, error) {
+	var sec Section
+	var current *Subsection
+	var body []string
+	inMeta := false
+
+	flush := func() {
+		if current != nil {
+			current.Content = strings.Trim(strings.Join(body, "\n"), "\n")
+			sec.Subsections = append(sec.Subsections, *current)
+		}
+		body = nil
+	}
+
+	scanner := bufio.NewScanner(strings.NewReader(data))
+	lineNo := 0
+	for scanner.Scan() {
+		line := scanner.Text()
+		lineNo++
+		switch {
+		case strings.HasPrefix(line, "## "):
+			flush()
+			current = &Subsection{Name: strings.TrimSpace(line[3:]), Lang: "go"}
+			inMeta = true
+		case inMeta && metaLinePattern.MatchString(line):
+			key, value, _ := strings.Cut(line, ":")
+			if err := setSubsectionMeta(current, key, strings.TrimSpace(value)); err != nil {
+				return sec, fmt.Errorf("%s:%d: %w", path, lineNo, err)
+			}
+		case current != nil:
+			inMeta = false
+			body = append(body, line)
+		case strings.HasPrefix(line, "# "):
+			sec.Name = strings.TrimSpace(line[2:])
+		case strings.HasPrefix(line, "short:"):
+			sec.Short = strings.TrimSpace(strings.TrimPrefix(line, "short:"))
+		case strings.TrimSpace(line) == "":
+		default:
+			return sec, fmt.Errorf("%s:%d: unexpected line %q before first subsection", path, lineNo, line)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return sec, fmt.Errorf("%s: %w", path, err)
+	}
+	flush()
+
+	if sec.Name == "" {
+		return sec, fmt.Errorf("%s: missing \"# <SectionName>\" heading", path)
+	}
+	return sec, nil
+}
+
+func setSubsectionMeta(sub *Subsection, key string, value string) error {
+	switch key {
+	case "lang":
+		sub.Lang = value
+	case "kind":
+		if value != KindCode && value != KindTemplate {
+			return fmt.Errorf("invalid subsection kind %q, want %s or %s", value, KindCode, KindTemplate)
+		}
+		sub.Kind = value
+	case "minGo", "maxGo":
+		if !ValidGoVersion(value) {
+			return fmt.Errorf("invalid Go version %q for %s, want a release such as 1.22", value, key)
+		}
+		if key == "minGo" {
+			sub.MinGo = value
+		} else {
+			sub.MaxGo = value
+		}
+	default:
+		return fmt.Errorf("unknown subsection metadata %q", key)
+	}
+	return nil
+}
@@ -0,0 +1,166 @@
+package reference
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// Parse
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name        string
+		data        string
+		want        Section
+		wantErr     bool
+		errContains string
+	}{
+		{
+			name: "section with subsections",
+			data: "# Variables\nshort: var\n\n## Declaration\n\n{kw:var} x int\n\n## Types\n\n\tbool\n\tstring\n",
+			want: Section{
+				Name:  "Variables",
+				Short: "var",
+				Subsections: []Subsection{
+					{Name: "Declaration", Lang: "go", Content: "{kw:var} x int"},
+					{Name: "Types", Lang: "go", Content: "\tbool\n\tstring"},
+				},
+			},
+		},
+		{
+			name: "subsection metadata",
+			data: "# BuildRun\nshort: build\n\n## Commands\nlang: sh\n\n{title:Build}:\n\tgo build\n",
+			want: Section{
+				Name:  "BuildRun",
+				Short: "build",
+				Subsections: []Subsection{
+					{Name: "Commands", Lang: "sh", Content: "{title:Build}:\n\tgo build"},
+				},
+			},
+		},
+		{
+			name: "subsection kind",
+			data: "# Functions\n\n## Declaration\nkind: code\n\n\tfunc f() {}\n\n## Template\nlang: go\nkind: template\n\n\tfunc <name>() {}\n",
+			want: Section{
+				Name: "Functions",
+				Subsections: []Subsection{
+					{Name: "Declaration", Lang: "go", Kind: KindCode, Content: "\tfunc f() {}"},
+					{Name: "Template", Lang: "go", Kind: KindTemplate, Content: "\tfunc <name>() {}"},
+				},
+			},
+		},
+		{
+			name: "subsection Go versions",
+			data: "# Loops\n\n## RangeOverInt\nkind: code\nminGo: 1.22\n\n\tfor range 3 {}\n\n## Old\nmaxGo: go1.21.3\n\nold\n",
+			want: Section{
+				Name: "Loops",
+				Subsections: []Subsection{
+					{Name: "RangeOverInt", Lang: "go", Kind: KindCode, MinGo: "1.22", Content: "\tfor range 3 {}"},
+					{Name: "Old", Lang: "go", MaxGo: "go1.21.3", Content: "old"},
+				},
+			},
+		},
+		{
+			name:        "invalid Go version",
+			data:        "# Loops\n\n## RangeOverInt\nminGo: latest\n\nfor range 3 {}\n",
+			wantErr:     true,
+			errContains: "invalid Go version \"latest\" for minGo",
+		},
+		{
+			name:        "invalid subsection kind",
+			data:        "# Functions\n\n## Declaration\nkind: snippet\n\nfunc f() {}\n",
+			wantErr:     true,
+			errContains: "invalid subsection kind",
+		},
+		{
+			name:        "unknown subsection metadata",
+			data:        "# BuildRun\n\n## Commands\nsyntax: sh\n\ngo build\n",
+			wantErr:     true,
+			errContains: "unknown subsection metadata",
+		},
+		{
+			name:        "missing heading",
+			data:        "short: var\n\n## Declaration\n\nvar x int\n",
+			wantErr:     true,
+			errContains: "test.md: missing \"# <SectionName>\" heading",
+		},
+		{
+			name:        "stray text before subsections",
+			data:        "# Variables\nsomething\n",
+			wantErr:     true,
+			errContains: "test.md:2: unexpected line",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse("test.md", tt.data)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Parse() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				if !strings.HasPrefix(err.Error(), "test.md:") || !strings.Contains(err.Error(), tt.errContains) {
+					t.Errorf("Parse() error = %v, want contains %q", err, tt.errContains)
+				}
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Parse() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+// Merge
+func TestMerge(t *testing.T) {
+	builtin := []Section{
+		{Name: "Variables", Short: "var", Subsections: []Subsection{
+			{Name: "Declaration", Content: "var x int"},
+			{Name: "Types", Content: "int"},
+		}},
+	}
+
+	tests := []struct {
+		name string
+		user []Section
+		want []Section
+	}{
+		{
+			name: "override by short name",
+			user: []Section{{Name: "var", Origin: "u.md", Subsections: []Subsection{{Name: "types", Origin: "u.md", Content: "string"}}}},
+			want: []Section{
+				{Name: "Variables", Short: "var", Subsections: []Subsection{
+					{Name: "Declaration", Content: "var x int"},
+					{Name: "types", Origin: "u.md", Content: "string"},
+				}},
+			},
+		},
+		{
+			name: "extend by name",
+			user: []Section{{Name: "variables", Origin: "u.md", Subsections: []Subsection{{Name: "Shadowing", Origin: "u.md", Content: "x := 1"}}}},
+			want: []Section{
+				{Name: "Variables", Short: "var", Subsections: []Subsection{
+					{Name: "Declaration", Content: "var x int"},
+					{Name: "Types", Content: "int"},
+					{Name: "Shadowing", Origin: "u.md", Content: "x := 1"},
+				}},
+			},
+		},
+		{
+			name: "new section",
+			user: []Section{{Name: "Logging", Short: "log", Origin: "u.md"}},
+			want: append(append([]Section(nil), builtin...), Section{Name: "Logging", Short: "log", Origin: "u.md"}),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Merge(builtin, tt.user); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Merge() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+	if len(builtin[0].Subsections) != 2 {
+		t.Error("Merge() modified the built-in sections")
+	}
+}
@@ -0,0 +1,173 @@
+package reference
+
+import (
+	"html"
+	"sort"
+	"strings"
+)
+
+// Subsection content is written in a small inline markup: {role:text} marks
+// text with a semantic role, and everything else is plain text. Braces that
+// do not open a known role are copied through untouched, so Go code such as
+// "T{Name: v}" needs no escaping; inside a span, "\}" and "\\" escape a
+// closing brace or backslash.
+const (
+	RoleTitle       = "title" // snippet title
+	RoleHeading     = "head"  // heading inside a snippet
+	RoleKeyword     = "kw"    // keyword, builtin or command
+	RolePlaceholder = "ph"    // <placeholder>, type or identifier
+	RoleLiteral     = "lit"   // literal or example value
+	RoleOperator    = "op"    // operator or condition
+	RoleFlow        = "flow"  // control-flow statement such as break
+	RoleLabel       = "label" // statement label
+	RoleNote        = "note"  // comment or aside
+
+	// Roles assigned by the Go highlighter in highlight.go.
+	RoleIdent   = "ident"   // identifier
+	RoleBuiltin = "builtin" // predeclared type, function or constant
+)
+
+// markupRoles is the set of roles ParseMarkup recognises.
+var markupRoles = map[string]bool{
+	RoleTitle:       true,
+	RoleHeading:     true,
+	RoleKeyword:     true,
+	RolePlaceholder: true,
+	RoleLiteral:     true,
+	RoleOperator:    true,
+	RoleFlow:        true,
+	RoleLabel:       true,
+	RoleNote:        true,
+	RoleIdent:       true,
+	RoleBuiltin:     true,
+}
+
+// IsRole reports whether role is a markup role.
+func IsRole(role string) bool {
+	return markupRoles[role]
+}
+
+// Roles returns every markup role in sorted order.
+func Roles() []string {
+	var roles []string
+	for role := range markupRoles {
+		roles = append(roles, role)
+	}
+	sort.Strings(roles)
+	return roles
+}
+
+// Span is a run of content text with the role it was marked or
+// highlighted with.
+type Span struct {
+	Role string // empty for plain text
+	Text string
+}
+
+// Renderer renders a single span; role is empty for plain text. Plain,
+// HTML and Markdown are provided, and any function of this type can be
+// passed to Render to style content another way, such as for a terminal.
+type Renderer func(role string, text string) string
+
+// Plain renders text without its roles.
+func Plain(role string, text string) string {
+	return text
+}
+
+// HTML renders a span as a <span> with the role as its class.
+func HTML(role string, text string) string {
+	if role == "" {
+		return html.EscapeString(text)
+	}
+	return "<span class=\"" + role + "\">" + html.EscapeString(text) + "</span>"
+}
+
+// Markdown renders titles and headings in bold and everything else as it
+// is.
+func Markdown(role string, text string) string {
+	switch role {
+	case RoleTitle, RoleHeading:
+		return "**" + text + "**"
+	default:
+		return text
+	}
+}
+
+// Spans parses a subsection's content, syntax highlighting the plain text
+// of Go snippets.
+func Spans(sub Subsection) []Span {
+	spans := ParseMarkup(sub.Content)
+	if sub.Lang == "" || sub.Lang == "go" {
+		spans = Highlight(spans)
+	}
+	return spans
+}
+
+// Render renders a subsection's content with render, syntax highlighting
+// the plain text of Go snippets.
+func Render(sub Subsection, render Renderer) string {
+	return RenderSpans(Spans(sub), render)
+}
+
+// RenderMarkup renders markup without highlighting.
+func RenderMarkup(s string, render Renderer) string {
+	return RenderSpans(ParseMarkup(s), render)
+}
+
+func RenderSpans(spans []Span, render Renderer) string {
+	var out strings.Builder
+	for _, span := range spans {
+		out.WriteString(render(span.Role, span.Text))
+	}
+	return out.String()
+}
+
+func ParseMarkup(s string) []Span {
+	var spans []Span
+	start := 0
+	for i := 0; i < len(s); i++ {
+		if s[i] != '{' {
+			continue
+		}
+		role, text, n, ok := scanMarkupSpan(s[i:])
+		if !ok {
+			continue
+		}
+		if i > start {
+			spans = append(spans, Span{Text: s[start:i]})
+		}
+		spans = append(spans, Span{Role: role, Text: text})
+		i += n - 1
+		start = i + 1
+	}
+	if start < len(s) {
+		spans = append(spans, Span{Text: s[start:]})
+	}
+	return spans
+}
+
+// scanMarkupSpan reports whether s starts with a {role:text} span for a
+// known role, returning the role, the unescaped text and the span length.
+func scanMarkupSpan(s string) (role string, text string, n int, ok bool) {
+	colon := strings.IndexByte(s, ':')
+	if colon < 0 {
+		return "", "", 0, false
+	}
+	role = s[1:colon]
+	if !markupRoles[role] {
+		return "", "", 0, false
+	}
+	var b strings.Builder
+	for i := colon + 1; i < len(s); i++ {
+		switch {
+		case s[i] == '\\' && i+1 < len(s) && (s[i+1] == '}' || s[i+1] == '\\'):
+			b.WriteByte(s[i+1])
+			i++
+		case s[i] == '}':
+			return role, b.String(), i + 1, true
+		default:
+			b.WriteByte(s[i])
+		}
+	}
+	return "", "", 0, false
+}
@@ -0,0 +1,52 @@
+package reference
+
+import (
+	"reflect"
+	"testing"
+)
+
+// Parse Markup
+func TestParseMarkup(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want []Span
+	}{
+		{"plain text", "for {", []Span{{Text: "for {"}}},
+		{"known role", "{kw:for} i", []Span{{Role: "kw", Text: "for"}, {Text: " i"}}},
+		{"unknown role is literal", "T{Name: v}", []Span{{Text: "T{Name: v}"}}},
+		{"escaped brace", `{lit:a\}b}`, []Span{{Role: "lit", Text: "a}b"}}},
+		{"unterminated span is literal", "{kw:for", []Span{{Text: "{kw:for"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ParseMarkup(tt.in); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseMarkup(%q) = %+v, want %+v", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+// Render Markup
+func TestRenderMarkup(t *testing.T) {
+	in := `{title:Loops}: {kw:for} {ph:<i>} < {lit:"x"}`
+
+	tests := []struct {
+		name   string
+		render Renderer
+		want   string
+	}{
+		{"plain", Plain, `Loops: for <i> < "x"`},
+		{"html", HTML, `<span class="title">Loops</span>: <span class="kw">for</span> <span class="ph">&lt;i&gt;</span> &lt; <span class="lit">&#34;x&#34;</span>`},
+		{"markdown", Markdown, `**Loops**: for <i> < "x"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := RenderMarkup(in, tt.render); got != tt.want {
+				t.Errorf("RenderMarkup() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
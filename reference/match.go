@@ -0,0 +1,278 @@
+package reference
+
+import (
+	"sort"
+	"strings"
+)
+
+// MatchName resolves query against a list of items, each known by one or
+// more names (for a section, its name and short name), ignoring case:
+//
+//  1. an exact match on any name wins;
+//  2. otherwise a prefix of exactly one item's names resolves to it;
+//  3. otherwise a subsequence of exactly one item's names resolves to it
+//     ("dtstr" for DataStructures).
+//
+// It returns the index of the resolved item, or -1. When a step matches
+// more than one item, candidates holds their indexes, best match first, so
+// the caller can list them instead of failing outright.
+func MatchName(query string, names [][]string) (match int, candidates []int) {
+	query = strings.ToLower(query)
+	if query == "" {
+		return -1, nil
+	}
+
+	for i, aliases := range names {
+		for _, name := range aliases {
+			if strings.EqualFold(name, query) {
+				return i, nil
+			}
+		}
+	}
+
+	var prefixed []int
+	for i, aliases := range names {
+		for _, name := range aliases {
+			if name != "" && strings.HasPrefix(strings.ToLower(name), query) {
+				prefixed = append(prefixed, i)
+				break
+			}
+		}
+	}
+	if len(prefixed) == 1 {
+		return prefixed[0], nil
+	}
+	if len(prefixed) > 1 {
+		return -1, prefixed
+	}
+
+	if len(query) < 2 {
+		return -1, nil
+	}
+	scores := map[int]int{}
+	var fuzzy []int
+	for i, aliases := range names {
+		best := -1
+		for _, name := range aliases {
+			if score := SubsequenceScore(query, strings.ToLower(name)); score >= 0 && (best < 0 || score < best) {
+				best = score
+			}
+		}
+		if best >= 0 {
+			scores[i] = best
+			fuzzy = append(fuzzy, i)
+		}
+	}
+	if len(fuzzy) == 1 {
+		return fuzzy[0], nil
+	}
+	sort.SliceStable(fuzzy, func(a, b int) bool { return scores[fuzzy[a]] < scores[fuzzy[b]] })
+	return -1, fuzzy
+}
+
+// SubsequenceScore returns how loosely query is spread over name, as the
+// number of skipped characters between its first and last matched
+// character, or -1 if query is not a subsequence of name. Lower is better.
+func SubsequenceScore(query string, name string) int {
+	start, q := -1, 0
+	for i := 0; i < len(name) && q < len(query); i++ {
+		if name[i] == query[q] {
+			if start < 0 {
+				start = i
+			}
+			q++
+			if q == len(query) {
+				return i + 1 - start - len(query)
+			}
+		}
+	}
+	return -1
+}
+
+// SectionNames returns the names MatchName and SuggestNames know each
+// section by.
+func SectionNames(sections []Section) [][]string {
+	names := make([][]string, len(sections))
+	for i, sec := range sections {
+		names[i] = []string{sec.Name, sec.Short}
+	}
+	return names
+}
+
+// SubsectionNames returns the name of each subsection of sec.
+func SubsectionNames(sec Section) [][]string {
+	names := make([][]string, len(sec.Subsections))
+	for i, sub := range sec.Subsections {
+		names[i] = []string{sub.Name}
+	}
+	return names
+}
+
+// MatchSection resolves name to a section with MatchName, returning its
+// index or -1 and the names of the candidates when name is ambiguous.
+func MatchSection(sections []Section, name string) (int, []string) {
+	i, candidates := MatchName(name, SectionNames(sections))
+	var names []string
+	for _, c := range candidates {
+		names = append(names, sections[c].Name)
+	}
+	return i, names
+}
+
+// MatchSubsection resolves name to a subsection of sec with MatchName,
+// returning its index or -1 and the names of the candidates when name is
+// ambiguous.
+func MatchSubsection(sec Section, name string) (int, []string) {
+	i, candidates := MatchName(name, SubsectionNames(sec))
+	var names []string
+	for _, c := range candidates {
+		names = append(names, sec.Subsections[c].Name)
+	}
+	return i, names
+}
+
+// SuggestNames returns up to three of names closest to query, ignoring
+// case, closest first. A name is close when query is a few edits away from
+// it, or from its prefix of the same length so that mistyped abbreviations
+// are caught too; roughly one edit per three characters is allowed.
+func SuggestNames(query string, names [][]string) []int {
+	query = strings.ToLower(query)
+	distances := map[int]int{}
+	var close []int
+	for i, aliases := range names {
+		best := -1
+		for _, name := range aliases {
+			if name == "" {
+				continue
+			}
+			name = strings.ToLower(name)
+			d := editDistance(query, name)
+			if len(query) >= 3 && len(query) < len(name) {
+				d = min(d, editDistance(query, name[:len(query)]))
+			}
+			if d <= max(1, min(len(query), len(name))/3) && (best < 0 || d < best) {
+				best = d
+			}
+		}
+		if best >= 0 {
+			distances[i] = best
+			close = append(close, i)
+		}
+	}
+	sort.SliceStable(close, func(a, b int) bool { return distances[close[a]] < distances[close[b]] })
+	if len(close) > 3 {
+		close = close[:3]
+	}
+	return close
+}
+
+// editDistance returns the optimal string alignment distance between a and
+// b: the number of insertions, deletions, substitutions and transpositions
+// of adjacent characters needed to turn one into the other.
+func editDistance(a string, b string) int {
+	d := make([][]int, len(a)+1)
+	for i := range d {
+		d[i] = make([]int, len(b)+1)
+		d[i][0] = i
+	}
+	for j := range d[0] {
+		d[0][j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			d[i][j] = min(d[i-1][j]+1, d[i][j-1]+1, d[i-1][j-1]+cost)
+			if i > 1 && j > 1 && a[i-1] == b[j-2] && a[i-2] == b[j-1] {
+				d[i][j] = min(d[i][j], d[i-2][j-2]+1)
+			}
+		}
+	}
+	return d[len(a)][len(b)]
+}
+
+// SuggestSections returns the names of up to three sections close to name.
+func SuggestSections(sections []Section, name string) []string {
+	var names []string
+	for _, i := range SuggestNames(name, SectionNames(sections)) {
+		names = append(names, sections[i].Name)
+	}
+	return names
+}
+
+// SuggestSubsections returns the names of up to three subsections of sec
+// close to name.
+func SuggestSubsections(sec Section, name string) []string {
+	var names []string
+	for _, i := range SuggestNames(name, SubsectionNames(sec)) {
+		names = append(names, sec.Subsections[i].Name)
+	}
+	return names
+}
+
+// Ref locates a subsection within a []Section by index.
+type Ref struct {
+	Section    int
+	Subsection int
+}
+
+func allSubsections(sections []Section) ([]Ref, [][]string) {
+	var refs []Ref
+	var names [][]string
+	for i, sec := range sections {
+		for j, sub := range sec.Subsections {
+			refs = append(refs, Ref{i, j})
+			names = append(names, []string{sub.Name})
+		}
+	}
+	return refs, names
+}
+
+// MatchAnySubsection resolves name against the subsections of every
+// section. Unlike MatchName, an exact name shared by subsections of several
+// sections (such as "Basic") is ambiguous rather than resolving to the
+// first. With exactOnly set, only exact names are considered.
+func MatchAnySubsection(sections []Section, name string, exactOnly bool) (Ref, []Ref, bool) {
+	refs, names := allSubsections(sections)
+	var exact []Ref
+	for i, aliases := range names {
+		if strings.EqualFold(aliases[0], name) {
+			exact = append(exact, refs[i])
+		}
+	}
+	switch {
+	case len(exact) == 1:
+		return exact[0], nil, true
+	case len(exact) > 1 || exactOnly:
+		return Ref{}, exact, false
+	}
+
+	i, candidates := MatchName(name, names)
+	if i >= 0 {
+		return refs[i], nil, true
+	}
+	var matched []Ref
+	for _, c := range candidates {
+		matched = append(matched, refs[c])
+	}
+	return Ref{}, matched, false
+}
+
+// SuggestAnySubsections returns up to three "Section/Subsection" paths of
+// subsections, from any section, close to name.
+func SuggestAnySubsections(sections []Section, name string) []string {
+	refs, names := allSubsections(sections)
+	var paths []string
+	for _, i := range SuggestNames(name, names) {
+		paths = append(paths, Path(sections, refs[i]))
+	}
+	return paths
+}
+
+// Path returns "Section/Subsection" for ref.
+func Path(sections []Section, ref Ref) string {
+	sec := sections[ref.Section]
+	return sec.Name + "/" + sec.Subsections[ref.Subsection].Name
+}
@@ -0,0 +1,121 @@
+package reference
+
+import (
+	"reflect"
+	"testing"
+)
+
+// Match Name
+func TestMatchName(t *testing.T) {
+	names := [][]string{
+		{"Conditionals", "cond"},
+		{"Concurrency", "concurrent"},
+		{"DataStructures", "ds"},
+		{"Functions", "func"},
+	}
+
+	tests := []struct {
+		name           string
+		query          string
+		wantMatch      int
+		wantCandidates []int
+	}{
+		{"exact name", "functions", 3, nil},
+		{"exact short name", "DS", 2, nil},
+		{"unique prefix", "Data", 2, nil},
+		{"unique prefix of short name", "fun", 3, nil},
+		{"ambiguous prefix", "con", -1, []int{0, 1}},
+		{"unique subsequence", "dtstr", 2, nil},
+		{"ambiguous subsequence ranked", "cnt", -1, []int{0, 1}},
+		{"no match", "xyz", -1, nil},
+		{"empty query", "", -1, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			match, candidates := MatchName(tt.query, names)
+			if match != tt.wantMatch || !reflect.DeepEqual(candidates, tt.wantCandidates) {
+				t.Errorf("MatchName(%q) = %d, %v, want %d, %v", tt.query, match, candidates, tt.wantMatch, tt.wantCandidates)
+			}
+		})
+	}
+}
+
+// Suggest Names
+func TestSuggestNames(t *testing.T) {
+	names := [][]string{
+		{"Loops", "loop"},
+		{"Pointers", "ptr"},
+		{"Functions", "func"},
+		{"Generics", "gen"},
+	}
+
+	tests := []struct {
+		name  string
+		query string
+		want  []int
+	}{
+		{"one edit away", "Loosp", []int{0}},
+		{"short name typo", "pt", []int{1}},
+		{"closest first", "fnuc", []int{2}},
+		{"nothing close", "zzzzzz", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SuggestNames(tt.query, names); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("SuggestNames(%q) = %v, want %v", tt.query, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEditDistance(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"", "abc", 3},
+		{"switch", "switch", 0},
+		{"swtich", "switch", 1},
+		{"kitten", "sitting", 3},
+		{"condtionals", "conditionals", 1},
+	}
+	for _, tt := range tests {
+		if got := editDistance(tt.a, tt.b); got != tt.want {
+			t.Errorf("editDistance(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+// Match Any Subsection
+func TestMatchAnySubsection(t *testing.T) {
+	sections := []Section{
+		{Name: "Goroutines", Subsections: []Subsection{{Name: "Basic"}, {Name: "WaitGroups"}}},
+		{Name: "Generics", Subsections: []Subsection{{Name: "Basic"}, {Name: "Constraints"}}},
+	}
+
+	tests := []struct {
+		name           string
+		query          string
+		exactOnly      bool
+		wantRef        Ref
+		wantCandidates []Ref
+		wantOK         bool
+	}{
+		{"unique exact", "waitgroups", true, Ref{0, 1}, nil, true},
+		{"shared exact name", "Basic", false, Ref{}, []Ref{{0, 0}, {1, 0}}, false},
+		{"prefix skipped when exact only", "wait", true, Ref{}, nil, false},
+		{"prefix", "wait", false, Ref{0, 1}, nil, true},
+		{"ambiguous prefix", "b", false, Ref{}, []Ref{{0, 0}, {1, 0}}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ref, candidates, ok := MatchAnySubsection(sections, tt.query, tt.exactOnly)
+			if ref != tt.wantRef || !reflect.DeepEqual(candidates, tt.wantCandidates) || ok != tt.wantOK {
+				t.Errorf("MatchAnySubsection(%q) = %v, %v, %v, want %v, %v, %v", tt.query, ref, candidates, ok, tt.wantRef, tt.wantCandidates, tt.wantOK)
+			}
+		})
+	}
+}
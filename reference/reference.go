@@ -0,0 +1,158 @@
+// Package reference is the Go syntax reference behind the gosyn command:
+// its sections of snippets, the inline markup they are written in, and
+// the name matching and search used to find them.
+//
+// Sections are usually loaded with Builtin, optionally merged with a
+// directory of your own with Load and Merge, and queried through a
+// Registry:
+//
+//	sections, err := reference.Builtin()
+//	if err != nil {
+//		return err
+//	}
+//	reg := reference.NewRegistry(sections)
+//	_, sub, err := reg.Lookup("loops", "range")
+//	if err != nil {
+//		return err
+//	}
+//	fmt.Println(reference.Render(sub, reference.Plain))
+//
+// Render takes any Renderer, so content can be styled for a terminal, a
+// web page or a chat message alike.
+package reference
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Section is a topic such as Loops, made of subsections.
+type Section struct {
+	Name        string
+	Short       string // short name, such as "ds" for DataStructures
+	Origin      string // file a user-defined section was loaded from, empty for built-in
+	Subsections []Subsection
+}
+
+// Subsection is a single snippet of a section.
+type Subsection struct {
+	Name    string
+	Lang    string // go, sh, gomod or text
+	Kind    string // KindCode or KindTemplate, empty when untagged
+	MinGo   string // first Go release the content applies to, such as "1.18", empty when any
+	MaxGo   string // last Go release the content applies to, empty when still current
+	Origin  string // file a user-defined subsection was loaded from, empty for built-in
+	Content string // written in the markup described in markup.go
+}
+
+// A Go snippet is either real code, complete enough to compile once
+// wrapped in a program, or a template whose <placeholders> stand for
+// code.
+const (
+	KindCode     = "code"
+	KindTemplate = "template"
+)
+
+// NotFoundError is returned when no section or subsection fits a name.
+type NotFoundError struct {
+	Kind        string   // "section" or "subsection"
+	Name        string   // the name asked for
+	Section     string   // the section searched, for a subsection
+	Suggestions []string // up to three close names, closest first
+}
+
+func (e *NotFoundError) Error() string {
+	msg := fmt.Sprintf("%s %q not found", e.Kind, e.Name)
+	if e.Section != "" {
+		msg += fmt.Sprintf(" in section %q", e.Section)
+	}
+	if len(e.Suggestions) > 0 {
+		msg += ", did you mean " + strings.Join(e.Suggestions, " or ") + "?"
+	}
+	return msg
+}
+
+// AmbiguousError is returned when a name fits several sections or
+// subsections equally well.
+type AmbiguousError struct {
+	Kind       string   // "section" or "subsection"
+	Name       string   // the name asked for
+	Section    string   // the section searched, for a subsection
+	Candidates []string // the names it fits, best first
+}
+
+func (e *AmbiguousError) Error() string {
+	msg := fmt.Sprintf("%s %q is ambiguous", e.Kind, e.Name)
+	if e.Section != "" {
+		msg += fmt.Sprintf(" in section %q", e.Section)
+	}
+	return msg + ", could be: " + strings.Join(e.Candidates, ", ")
+}
+
+// LookupSection resolves name to the index of a section with MatchSection,
+// returning a *NotFoundError or *AmbiguousError when it cannot.
+func LookupSection(sections []Section, name string) (int, error) {
+	i, candidates := MatchSection(sections, name)
+	switch {
+	case i >= 0:
+		return i, nil
+	case len(candidates) > 0:
+		return -1, &AmbiguousError{Kind: "section", Name: name, Candidates: candidates}
+	default:
+		return -1, &NotFoundError{Kind: "section", Name: name, Suggestions: SuggestSections(sections, name)}
+	}
+}
+
+// LookupSubsection resolves name to the index of a subsection of sec with
+// MatchSubsection, returning a *NotFoundError or *AmbiguousError when it
+// cannot.
+func LookupSubsection(sec Section, name string) (int, error) {
+	j, candidates := MatchSubsection(sec, name)
+	switch {
+	case j >= 0:
+		return j, nil
+	case len(candidates) > 0:
+		return -1, &AmbiguousError{Kind: "subsection", Name: name, Section: sec.Name, Candidates: candidates}
+	default:
+		return -1, &NotFoundError{Kind: "subsection", Name: name, Section: sec.Name, Suggestions: SuggestSubsections(sec, name)}
+	}
+}
+
+// Registry answers queries over a fixed set of sections.
+type Registry struct {
+	sections []Section
+}
+
+// NewRegistry returns a Registry over sections, in the order given.
+func NewRegistry(sections []Section) *Registry {
+	return &Registry{sections: sections}
+}
+
+// List returns every section, in order.
+func (r *Registry) List() []Section {
+	return append([]Section(nil), r.sections...)
+}
+
+// Lookup resolves a section name and a subsection name within it, each of
+// which may be abbreviated as MatchName allows. With subsectionName empty
+// only the section is looked up and the returned Subsection is zero.
+func (r *Registry) Lookup(sectionName string, subsectionName string) (Section, Subsection, error) {
+	i, err := LookupSection(r.sections, sectionName)
+	if err != nil {
+		return Section{}, Subsection{}, err
+	}
+	sec := r.sections[i]
+	if subsectionName == "" {
+		return sec, Subsection{}, nil
+	}
+	j, err := LookupSubsection(sec, subsectionName)
+	if err != nil {
+		return Section{}, Subsection{}, err
+	}
+	return sec, sec.Subsections[j], nil
+}
+
+// Search looks for query in every subsection, see Search.
+func (r *Registry) Search(query string, useRegex bool) ([]SearchResult, error) {
+	return Search(r.sections, query, useRegex)
+}
@@ -0,0 +1,82 @@
+package reference
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// Registry Lookup
+func TestRegistryLookup(t *testing.T) {
+	reg := NewRegistry([]Section{
+		{Name: "Conditionals", Short: "cond", Subsections: []Subsection{{Name: "If"}, {Name: "IfElse"}, {Name: "Switch"}}},
+		{Name: "Concurrency", Short: "concurrent", Subsections: []Subsection{{Name: "Mutex"}}},
+	})
+
+	tests := []struct {
+		name           string
+		section        string
+		subsection     string
+		wantSection    string
+		wantSubsection string
+		wantErr        error
+	}{
+		{name: "prefixes", section: "Condit", subsection: "sw", wantSection: "Conditionals", wantSubsection: "Switch"},
+		{name: "section only", section: "concurrent", wantSection: "Concurrency"},
+		{name: "ambiguous section", section: "con", subsection: "If", wantErr: &AmbiguousError{Kind: "section", Name: "con", Candidates: []string{"Conditionals", "Concurrency"}}},
+		{name: "ambiguous subsection", section: "cond", subsection: "i", wantErr: &AmbiguousError{Kind: "subsection", Name: "i", Section: "Conditionals", Candidates: []string{"If", "IfElse"}}},
+		{name: "section not found", section: "Cnodit", wantErr: &NotFoundError{Kind: "section", Name: "Cnodit", Suggestions: []string{"Conditionals"}}},
+		{name: "subsection not found", section: "cond", subsection: "Swtich", wantErr: &NotFoundError{Kind: "subsection", Name: "Swtich", Section: "Conditionals", Suggestions: []string{"Switch"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sec, sub, err := reg.Lookup(tt.section, tt.subsection)
+			if tt.wantErr != nil {
+				if !reflect.DeepEqual(err, tt.wantErr) {
+					t.Fatalf("Lookup() error = %#v, want %#v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Lookup() error = %v", err)
+			}
+			if sec.Name != tt.wantSection || sub.Name != tt.wantSubsection {
+				t.Errorf("Lookup() = %s/%s, want %s/%s", sec.Name, sub.Name, tt.wantSection, tt.wantSubsection)
+			}
+		})
+	}
+}
+
+// Lookup Errors
+func TestLookupErrors(t *testing.T) {
+	var notFound *NotFoundError
+	err := error(&NotFoundError{Kind: "subsection", Name: "Swtich", Section: "Conditionals", Suggestions: []string{"Switch"}})
+	if !errors.As(err, &notFound) {
+		t.Fatal("errors.As() did not find a *NotFoundError")
+	}
+	if got, want := err.Error(), `subsection "Swtich" not found in section "Conditionals", did you mean Switch?`; got != want {
+		t.Errorf("NotFoundError.Error() = %q, want %q", got, want)
+	}
+	err = &AmbiguousError{Kind: "section", Name: "con", Candidates: []string{"Conditionals", "Concurrency"}}
+	if got, want := err.Error(), `section "con" is ambiguous, could be: Conditionals, Concurrency`; got != want {
+		t.Errorf("AmbiguousError.Error() = %q, want %q", got, want)
+	}
+}
+
+// Registry List
+func TestRegistryList(t *testing.T) {
+	sections, err := Builtin()
+	if err != nil {
+		t.Fatal(err)
+	}
+	reg := NewRegistry(sections)
+	list := reg.List()
+	if !reflect.DeepEqual(list, sections) {
+		t.Fatal("List() does not return the sections given to NewRegistry()")
+	}
+	list[0] = Section{}
+	if reg.List()[0].Name == "" {
+		t.Error("List() shares its slice with the registry")
+	}
+}
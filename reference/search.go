@@ -0,0 +1,63 @@
+package reference
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// SearchResult is a subsection that matched a search.
+type SearchResult struct {
+	Section    string
+	Subsection string
+	Score      int
+	Lines      []SearchLine
+}
+
+// SearchLine is a line of a subsection's content that matched a search.
+type SearchLine struct {
+	Number  int // 1-based line number in the plain rendered content
+	Text    string
+	Matches [][]int // byte offsets of each match in Text
+}
+
+// Search looks for query in the plain rendered content of every
+// subsection. The query is matched literally and ignoring case unless
+// useRegex is set, in which case it is compiled as a regular expression.
+// Results are ranked by number of matching lines, with a bonus when the
+// subsection or section name matches too.
+func Search(sections []Section, query string, useRegex bool) ([]SearchResult, error) {
+	pattern := "(?i)" + regexp.QuoteMeta(query)
+	if useRegex {
+		pattern = query
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("invalid regular expression %q: %w", query, err)
+	}
+
+	var results []SearchResult
+	for _, sec := range sections {
+		for _, sub := range sec.Subsections {
+			result := SearchResult{Section: sec.Name, Subsection: sub.Name}
+			for i, line := range strings.Split(Render(sub, Plain), "\n") {
+				if matches := re.FindAllStringIndex(line, -1); matches != nil {
+					result.Lines = append(result.Lines, SearchLine{Number: i + 1, Text: line, Matches: matches})
+					result.Score += len(matches)
+				}
+			}
+			if re.MatchString(sub.Name) {
+				result.Score += 10
+			}
+			if re.MatchString(sec.Name) {
+				result.Score += 5
+			}
+			if result.Score > 0 {
+				results = append(results, result)
+			}
+		}
+	}
+	sort.SliceStable(results, func(i, j int) bool { return results[i].Score > results[j].Score })
+	return results, nil
+}
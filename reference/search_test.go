@@ -0,0 +1,57 @@
+package reference
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// Search
+func TestSearch(t *testing.T) {
+	sections := []Section{
+		{Name: "ErrorHandling", Subsections: []Subsection{
+			{Name: "PanicRecover", Content: "{title:Panic and Recover}:\n\n\tif r := recover(); r != nil {\n\t}"},
+			{Name: "Basic", Content: "\tfile, err := os.Open(name)"},
+		}},
+		{Name: "Goroutines", Subsections: []Subsection{
+			{Name: "WaitGroups", Content: "\twg.Add(1)\n\tdefer wg.Done()\n\twg.Wait()"},
+		}},
+	}
+
+	tests := []struct {
+		name        string
+		query       string
+		useRegex    bool
+		want        []string // "Section Subsection", in rank order
+		errContains string
+	}{
+		{name: "literal ignores case", query: "RECOVER", want: []string{"ErrorHandling PanicRecover"}},
+		{name: "literal is not a regex", query: "wg.", want: []string{"Goroutines WaitGroups"}},
+		{name: "regex", query: `wg\.(Done|Add)`, useRegex: true, want: []string{"Goroutines WaitGroups"}},
+		{name: "ranked by matches and names", query: "r", want: []string{"ErrorHandling PanicRecover", "Goroutines WaitGroups", "ErrorHandling Basic"}},
+		{name: "no results", query: "FieldByName", want: nil},
+		{name: "invalid regex", query: "(", useRegex: true, errContains: "invalid regular expression"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results, err := Search(sections, tt.query, tt.useRegex)
+			if tt.errContains != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errContains) {
+					t.Fatalf("Search() error = %v, want contains %q", err, tt.errContains)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Search() error = %v", err)
+			}
+			var got []string
+			for _, r := range results {
+				got = append(got, r.Section+" "+r.Subsection)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Search(%q) = %v, want %v", tt.query, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,51 @@
+package reference
+
+import (
+	"go/version"
+	"strings"
+)
+
+// Go releases are written as in a go.mod go directive, such as "1.22" or
+// "1.22.3"; a leading "go", as in "go1.22", is accepted too. Only the
+// language version, "1.22" in both cases, decides what content applies.
+
+// ValidGoVersion reports whether v names a Go release.
+func ValidGoVersion(v string) bool {
+	return v != "" && version.IsValid(goPrefixed(v))
+}
+
+// Supports reports whether sub's content applies to Go release goVersion,
+// going by its MinGo and MaxGo. Every subsection supports an empty or
+// invalid goVersion.
+func (sub Subsection) Supports(goVersion string) bool {
+	if !ValidGoVersion(goVersion) {
+		return true
+	}
+	lang := version.Lang(goPrefixed(goVersion))
+	if sub.MinGo != "" && version.Compare(lang, version.Lang(goPrefixed(sub.MinGo))) < 0 {
+		return false
+	}
+	if sub.MaxGo != "" && version.Compare(lang, version.Lang(goPrefixed(sub.MaxGo))) > 0 {
+		return false
+	}
+	return true
+}
+
+// GoRange describes the Go releases sub applies to: "Go 1.18+", "Go 1.18
+// to 1.21" or "Go 1.21 and earlier", or "" when it applies to all of them.
+func (sub Subsection) GoRange() string {
+	minGo, maxGo := strings.TrimPrefix(sub.MinGo, "go"), strings.TrimPrefix(sub.MaxGo, "go")
+	switch {
+	case minGo != "" && maxGo != "":
+		return "Go " + minGo + " to " + maxGo
+	case minGo != "":
+		return "Go " + minGo + "+"
+	case maxGo != "":
+		return "Go " + maxGo + " and earlier"
+	}
+	return ""
+}
+
+func goPrefixed(v string) string {
+	return "go" + strings.TrimPrefix(v, "go")
+}
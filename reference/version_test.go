@@ -0,0 +1,53 @@
+package reference
+
+import "testing"
+
+// Valid Go Version
+func TestValidGoVersion(t *testing.T) {
+	for v, want := range map[string]bool{
+		"1.22":    true,
+		"1.22.3":  true,
+		"go1.18":  true,
+		"":        false,
+		"latest":  false,
+		"v1.22":   false,
+		"1.22.x":  false,
+		"go1.21 ": false,
+	} {
+		if got := ValidGoVersion(v); got != want {
+			t.Errorf("ValidGoVersion(%q) = %v, want %v", v, got, want)
+		}
+	}
+}
+
+// Supports
+func TestSupports(t *testing.T) {
+	tests := []struct {
+		name      string
+		sub       Subsection
+		goVersion string
+		want      bool
+		wantRange string
+	}{
+		{"untagged", Subsection{}, "1.0", true, ""},
+		{"no target", Subsection{MinGo: "1.22"}, "", true, "Go 1.22+"},
+		{"too old", Subsection{MinGo: "1.22"}, "1.21", false, "Go 1.22+"},
+		{"first release", Subsection{MinGo: "1.22"}, "1.22", true, "Go 1.22+"},
+		{"patch release", Subsection{MinGo: "1.22"}, "1.22.5", true, "Go 1.22+"},
+		{"go prefix", Subsection{MinGo: "go1.18"}, "go1.20", true, "Go 1.18+"},
+		{"last release", Subsection{MaxGo: "1.21"}, "1.21.9", true, "Go 1.21 and earlier"},
+		{"too new", Subsection{MaxGo: "1.21"}, "1.22", false, "Go 1.21 and earlier"},
+		{"within range", Subsection{MinGo: "1.18", MaxGo: "1.21"}, "1.20", true, "Go 1.18 to 1.21"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.sub.Supports(tt.goVersion); got != tt.want {
+				t.Errorf("Supports(%q) = %v, want %v", tt.goVersion, got, tt.want)
+			}
+			if got := tt.sub.GoRange(); got != tt.wantRange {
+				t.Errorf("GoRange() = %q, want %q", got, tt.wantRange)
+			}
+		})
+	}
+}
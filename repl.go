@@ -0,0 +1,331 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/bbarrington0099/gosyn/reference"
+)
+
+// historyLimit is how many lines of REPL history are kept between sessions.
+const historyLimit = 500
+
+// repl is an interactive session. It remembers the section and subsection
+// last shown, so names can be given relative to them and back can climb
+// the section tree.
+type repl struct {
+	sections   []reference.Section
+	section    int // -1 at the top level
+	subsection int // -1 unless a subsection is shown
+	out        io.Writer
+	editor     *lineEditor
+}
+
+// runREPL reads commands from in until exit, quit or end of input. When in
+// is a terminal, lines are edited in raw mode with history and completion;
+// otherwise they are read as they come, without a prompt.
+func runREPL(sections []reference.Section, in io.Reader, out io.Writer) error {
+	r := &repl{sections: sections, section: -1, subsection: -1, out: out}
+	file, isFile := in.(*os.File)
+	if !isFile || !isTerminal(file) {
+		return r.readLines(in, false)
+	}
+	return r.runTerminal(file, makeRaw)
+}
+
+// runTerminal reads commands from the terminal file, putting it in raw mode
+// with raw for each line. When raw mode is unavailable, as on platforms
+// without it, whole lines are read after a prompt, without editing.
+func (r *repl) runTerminal(file *os.File, raw func(fd uintptr) (*terminalState, error)) error {
+	fmt.Fprintf(r.out, "%sgosyn%s interactive mode, type %shelp%s for commands and %sexit%s to leave\n",
+		style(roleHeading), Reset, // gosyn
+		style(roleCommand), Reset, // help
+		style(roleCommand), Reset, // exit
+	)
+	state, err := raw(file.Fd())
+	if err != nil {
+		return r.readLines(file, true)
+	}
+	restoreTerminal(file.Fd(), state)
+
+	r.editor = newLineEditor(file, r.out, r.completeLine)
+	r.editor.history = loadHistory()
+	defer func() { saveHistory(r.editor.history) }()
+	for {
+		state, err := raw(file.Fd())
+		if err != nil {
+			return withKind(ErrTerminal, fmt.Errorf("cannot use the terminal: %w", err))
+		}
+		line, err := r.editor.readLine(r.prompt())
+		restoreTerminal(file.Fd(), state)
+		switch {
+		case errors.Is(err, errInterrupted):
+			continue
+		case err == io.EOF:
+			return nil
+		case err != nil:
+			return withKind(ErrTerminal, fmt.Errorf("cannot use the terminal: %w", err))
+		}
+		if line = strings.TrimSpace(line); line != "" {
+			history := r.editor.history
+			if len(history) == 0 || history[len(history)-1] != line {
+				r.editor.history = append(history, line)
+			}
+		}
+		if r.handle(line) {
+			return nil
+		}
+	}
+}
+
+// readLines runs the lines of in as they come, printing a prompt before
+// each when prompt is set.
+func (r *repl) readLines(in io.Reader, prompt bool) error {
+	scanner := bufio.NewScanner(in)
+	for {
+		if prompt {
+			fmt.Fprint(r.out, r.prompt())
+		}
+		if !scanner.Scan() {
+			return scanner.Err()
+		}
+		if r.handle(scanner.Text()) {
+			return nil
+		}
+	}
+}
+
+// handle runs one line, printing its output or error, and reports whether
+// the session should end.
+func (r *repl) handle(line string) bool {
+	words := splitWords(line)
+	if len(words) == 0 {
+		return false
+	}
+	output, quit, err := r.eval(words)
+	writeDiagnostics(r.out, true)
+	if err != nil {
+		fmt.Fprintln(r.out, describeError(err, true))
+	} else if output != "" {
+		fmt.Fprintln(r.out, output)
+	}
+	return quit
+}
+
+// eval runs a line split into words. Besides the commands runCommand
+// knows, it handles back, exit and quit, and resolves a lone name against
+// the subsections of the current section first.
+func (r *repl) eval(words []string) (output string, quit bool, err error) {
+	switch strings.ToLower(words[0]) {
+	case "exit", "quit":
+		return "", true, nil
+	case "back":
+		return r.back(), false, nil
+	case "h", "help":
+		return listActions() + replHelp(), false, nil
+	case "repl":
+		return "", false, nil
+	case "lsub", "listsubsections":
+		if len(words) == 1 && r.section >= 0 {
+			words = append(words, r.sections[r.section].Name)
+		}
+	}
+
+	if len(words) == 1 && r.section >= 0 && !isAction(words[0]) {
+		sec := r.sections[r.section]
+		if j, _ := reference.MatchSubsection(sec, words[0]); j >= 0 {
+			r.subsection = j
+			return r.run([]string{sec.Name, sec.Subsections[j].Name})
+		}
+		if strings.EqualFold(words[0], "all") {
+			r.subsection = -1
+			return r.run([]string{sec.Name, "all"})
+		}
+	}
+
+	return r.run(words)
+}
+
+// run parses and runs words as on the command line, following the
+// section or subsection shown.
+func (r *repl) run(words []string) (string, bool, error) {
+	cmd, err := parseCommand(words)
+	if err != nil {
+		return "", false, err
+	}
+	output, err := runCommand(r.sections, cmd)
+	if err == nil {
+		r.follow(cmd)
+	}
+	return output, false, err
+}
+
+// follow moves the session to the section or subsection a successful
+// command showed.
+func (r *repl) follow(cmd command) {
+	switch {
+	case cmd.doc == nil || cmd.help():
+	case cmd.doc.name() == "listSubsections":
+		if i, _ := reference.MatchSection(r.sections, cmd.args[0]); i >= 0 {
+			r.section, r.subsection = i, -1
+		}
+	case cmd.doc.name() != "":
+	case len(cmd.args) == 1:
+		if target, ok := resolveLookup(r.sections, cmd.args[0]); ok && target.candidates == nil {
+			r.section, r.subsection = target.section, target.subsection
+		}
+	default:
+		if i, _ := reference.MatchSection(r.sections, cmd.args[0]); i >= 0 {
+			j, _ := reference.MatchSubsection(r.sections[i], cmd.args[1])
+			r.section, r.subsection = i, j
+		}
+	}
+}
+
+// back climbs from a subsection to its section, listing its subsections,
+// and from a section to the top level.
+func (r *repl) back() string {
+	switch {
+	case r.subsection >= 0:
+		r.subsection = -1
+		output, _ := listSubsections(r.sections, r.sections[r.section].Name)
+		return output
+	case r.section >= 0:
+		r.section = -1
+		return ""
+	default:
+		return fmt.Sprintf("Already at the top level, use %sexit%s to leave", style(roleCommand), Reset)
+	}
+}
+
+func (r *repl) prompt() string {
+	location := ""
+	if r.section >= 0 {
+		sec := r.sections[r.section]
+		location = fmt.Sprintf(" %s%s%s", style(roleSection), sec.Name, Reset)
+		if r.subsection >= 0 {
+			location += fmt.Sprintf("/%s%s%s", style(roleSubsection), sec.Subsections[r.subsection].Name, Reset)
+		}
+	}
+	return "gosyn" + location + "> "
+}
+
+// completeLine returns completion candidates for the word ending before,
+// as on the command line, plus the REPL's own commands and the
+// subsections of the current section.
+func (r *repl) completeLine(before string) []string {
+	words := splitWords(before)
+	if before == "" || strings.HasSuffix(before, " ") {
+		words = append(words, "")
+	}
+	candidates := complete(r.sections, words)
+	if len(words) != 1 {
+		return candidates
+	}
+	extra := []string{"back", "exit", "quit"}
+	if r.section >= 0 {
+		for _, name := range complete(r.sections, []string{r.sections[r.section].Name, words[0]}) {
+			if !strings.HasPrefix(name, "-") {
+				extra = append(extra, name)
+			}
+		}
+	}
+	return filterCandidates(append(candidates, extra...), words[0])
+}
+
+func replHelp() string {
+	return fmt.Sprintf(("%sIn the REPL%s:\n" +
+		" - %s<subsectionName>%s: Show a subsection of the current section\n" +
+		" - %sback%s: Go back from a subsection to its section, or from a section to the top level\n" +
+		" - %s(exit | quit)%s: Leave the REPL, as does Ctrl-D\n"),
+		style(roleHeading), Reset, // In the REPL
+		style(roleArg), Reset, // subsectionName
+		style(roleCommand), Reset, // back
+		style(roleCommand), Reset, // exit
+	)
+}
+
+// splitWords splits a line into words at spaces, keeping text in single
+// or double quotes together so a search can contain spaces.
+func splitWords(line string) []string {
+	var words []string
+	var word strings.Builder
+	inWord := false
+	var quote rune
+	for _, c := range line {
+		switch {
+		case quote != 0 && c == quote:
+			quote = 0
+		case quote != 0:
+			word.WriteRune(c)
+		case c == '\'' || c == '"':
+			quote = c
+			inWord = true
+		case c == ' ' || c == '\t':
+			if inWord {
+				words = append(words, word.String())
+				word.Reset()
+				inWord = false
+			}
+		default:
+			word.WriteRune(c)
+			inWord = true
+		}
+	}
+	if inWord {
+		words = append(words, word.String())
+	}
+	return words
+}
+
+func historyPath() string {
+	dir := configDir()
+	if dir == "" {
+		return ""
+	}
+	return filepath.Join(dir, "history")
+}
+
+// loadHistory reads the REPL history saved by saveHistory, if any.
+func loadHistory() []string {
+	path := historyPath()
+	if path == "" {
+		return nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+	var history []string
+	for _, line := range strings.Split(string(data), "\n") {
+		if line != "" {
+			history = append(history, line)
+		}
+	}
+	return history
+}
+
+// saveHistory keeps the last historyLimit lines of history for the next
+// session. Failing to save is not worth interrupting the user's exit for,
+// so it is only warned about.
+func saveHistory(history []string) {
+	path := historyPath()
+	if path == "" || len(history) == 0 {
+		return
+	}
+	if len(history) > historyLimit {
+		history = history[len(history)-historyLimit:]
+	}
+	err := os.MkdirAll(filepath.Dir(path), 0o755)
+	if err == nil {
+		err = os.WriteFile(path, []byte(strings.Join(history, "\n")+"\n"), 0o600)
+	}
+	if err != nil {
+		warn(warnHistory, "history not saved: %v", err)
+	}
+}
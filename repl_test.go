@@ -0,0 +1,133 @@
+package main
+
+import (
+	"errors"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/bbarrington0099/gosyn/reference"
+)
+
+// REPL
+func TestREPL(t *testing.T) {
+	sections := []reference.Section{
+		{Name: "Variables", Short: "var", Subsections: []reference.Subsection{{Name: "Declaration", Content: "var x int"}, {Name: "Types", Content: "int, string"}}},
+		{Name: "Channels", Short: "chan", Subsections: []reference.Subsection{{Name: "Buffered", Content: "make(chan int, 3)"}, {Name: "Select", Content: "select {}"}}},
+	}
+
+	tests := []struct {
+		name           string
+		lines          []string
+		wantSection    int
+		wantSubsection int
+	}{
+		{"section by short name", []string{"chan"}, 1, -1},
+		{"section and subsection", []string{"chan Select"}, 1, 1},
+		{"subsection of current section", []string{"chan", "Sel"}, 1, 1},
+		{"lone subsection of any section", []string{"Types"}, 0, 1},
+		{"back to section", []string{"chan Select", "back"}, 1, -1},
+		{"back to top", []string{"chan Select", "back", "back"}, -1, -1},
+		{"error keeps location", []string{"chan", "Nope"}, 1, -1},
+		{"listSubsections enters section", []string{"lsub var"}, 0, -1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out strings.Builder
+			r := &repl{sections: sections, section: -1, subsection: -1, out: &out}
+			for _, line := range tt.lines {
+				if r.handle(line) {
+					t.Fatalf("handle(%q) ended the session", line)
+				}
+			}
+			if r.section != tt.wantSection || r.subsection != tt.wantSubsection {
+				t.Errorf("after %q at %d/%d, want %d/%d", tt.lines, r.section, r.subsection, tt.wantSection, tt.wantSubsection)
+			}
+		})
+	}
+}
+
+// Run REPL
+func TestRunREPL(t *testing.T) {
+	sections := []reference.Section{
+		{Name: "Channels", Short: "chan", Subsections: []reference.Subsection{{Name: "Select", Content: "select {}"}}},
+	}
+	var out strings.Builder
+	input := strings.NewReader("lsec\nchan Select\nexit\nlsec\n")
+	if err := runREPL(sections, input, &out); err != nil {
+		t.Fatalf("runREPL() error = %v", err)
+	}
+	if got := strings.Count(out.String(), "Sections"); got != 1 {
+		t.Errorf("runREPL() ran %d lsec commands, want 1 before exit:\n%s", got, out.String())
+	}
+	if !strings.Contains(stripANSI(out.String()), "select {}") {
+		t.Errorf("runREPL() output = %q, want the Select subsection", out.String())
+	}
+}
+
+// REPL Without Raw Mode
+func TestRunTerminalWithoutRawMode(t *testing.T) {
+	sections := []reference.Section{
+		{Name: "Channels", Short: "chan", Subsections: []reference.Subsection{{Name: "Select", Content: "select {}"}}},
+	}
+	in, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer in.Close()
+	w.WriteString("chan Select\nexit\n")
+	w.Close()
+
+	var out strings.Builder
+	r := &repl{sections: sections, section: -1, subsection: -1, out: &out}
+	failRaw := func(fd uintptr) (*terminalState, error) {
+		return nil, errors.New("raw terminal mode is not supported on this platform")
+	}
+	if err := r.runTerminal(in, failRaw); err != nil {
+		t.Fatalf("runTerminal() error = %v, want whole lines read instead", err)
+	}
+	output := stripANSI(out.String())
+	if !strings.Contains(output, "select {}") || !strings.Contains(output, "gosyn Channels/Select> ") {
+		t.Errorf("runTerminal() output = %q, want the Select subsection and a prompt", output)
+	}
+}
+
+// REPL Completion
+func TestREPLCompleteLine(t *testing.T) {
+	sections := []reference.Section{
+		{Name: "Channels", Short: "chan", Subsections: []reference.Subsection{{Name: "Buffered"}, {Name: "Select"}}},
+	}
+	r := &repl{sections: sections, section: 0, subsection: -1}
+
+	if got := r.completeLine("Bu"); !reflect.DeepEqual(got, []string{"Buffered"}) {
+		t.Errorf("completeLine(\"Bu\") = %q, want subsection of current section", got)
+	}
+	if got := r.completeLine("ba"); !reflect.DeepEqual(got, []string{"back"}) {
+		t.Errorf("completeLine(\"ba\") = %q, want back", got)
+	}
+	if got := r.completeLine("chan "); !reflect.DeepEqual(got, []string{"Buffered", "Select", "all", "-p", "--pager"}) {
+		t.Errorf("completeLine(\"chan \") = %q", got)
+	}
+}
+
+// Split Words
+func TestSplitWords(t *testing.T) {
+	tests := []struct {
+		line string
+		want []string
+	}{
+		{"chan Select", []string{"chan", "Select"}},
+		{"  lsec  ", []string{"lsec"}},
+		{`search -r "wg\.(Done|Add) x"`, []string{"search", "-r", `wg\.(Done|Add) x`}},
+		{"search ''", []string{"search", ""}},
+		{"", nil},
+	}
+
+	for _, tt := range tests {
+		if got := splitWords(tt.line); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("splitWords(%q) = %q, want %q", tt.line, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,223 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"go/version"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/bbarrington0099/gosyn/reference"
+)
+
+// runTimeout bounds how long a snippet may run, so that a server or an
+// endless loop cannot hang gosyn.
+const runTimeout = 10 * time.Second
+
+// runResult is what running a snippet printed and how it ended.
+type runResult struct {
+	section    string
+	subsection string
+	stdout     string
+	stderr     string
+	exitCode   int
+	timedOut   bool
+}
+
+// runSnippet builds the subsection named by sectionName and subsectionName
+// into a temporary module with the local go toolchain and runs it. Only
+// kind: code snippets can be run; templates are refused, and so are
+// snippets with only declarations and those needing a newer Go than the
+// toolchain.
+func runSnippet(sections []reference.Section, sectionName string, subsectionName string) (runResult, error) {
+	i, err := resolveSection(sections, sectionName, "")
+	if err != nil {
+		return runResult{}, err
+	}
+	sec := sections[i]
+	j, err := resolveSubsection(sec, subsectionName)
+	if err != nil {
+		return runResult{}, err
+	}
+	sub := sec.Subsections[j]
+	if sub.Lang != "go" || sub.Kind != reference.KindCode {
+		return runResult{}, withKind(ErrNotRunnable, fmt.Errorf("%s/%s is not a runnable program, only Go snippets tagged \"kind: code\" can be run, not templates with <placeholders>", sec.Name, sub.Name))
+	}
+	code := snippetCode(sub)
+	if !snippetRuns(code) {
+		return runResult{}, withKind(ErrNotRunnable, fmt.Errorf("%s/%s only declares things, it has no statements to run", sec.Name, sub.Name))
+	}
+	goTool, err := exec.LookPath("go")
+	if err != nil {
+		return runResult{}, withKind(ErrNotRunnable, fmt.Errorf("the go toolchain was not found: %v", err))
+	}
+	toolchain := goToolchain(goTool)
+	goVersion := snippetGoVersion(sub, toolchain)
+	if version.IsValid(toolchain) && version.Compare(version.Lang(toolchain), version.Lang("go"+goVersion)) < 0 {
+		return runResult{}, withKind(ErrNotRunnable, fmt.Errorf("%s/%s needs Go %s, but the go toolchain is %s", sec.Name, sub.Name, goVersion, strings.TrimPrefix(toolchain, "go")))
+	}
+
+	dir, err := os.MkdirTemp("", "gosyn-run-")
+	if err != nil {
+		return runResult{}, withKind(ErrNotRunnable, fmt.Errorf("cannot create a module for %s/%s: %w", sec.Name, sub.Name, err))
+	}
+	defer os.RemoveAll(dir)
+	files := map[string]string{
+		"go.mod":  "module snippet\n\ngo " + goVersion + "\n",
+		"main.go": useUnused(snippetProgram(code)),
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			return runResult{}, withKind(ErrNotRunnable, fmt.Errorf("cannot create a module for %s/%s: %w", sec.Name, sub.Name, err))
+		}
+	}
+
+	build := exec.Command(goTool, "build", "-o", "snippet")
+	build.Dir = dir
+	build.Env = append(os.Environ(), "GOFLAGS=-mod=mod", "GOPROXY=off", "GOWORK=off")
+	if output, err := build.CombinedOutput(); err != nil {
+		return runResult{}, withKind(ErrNotRunnable, fmt.Errorf("%s/%s does not build:\n%s\n%s", sec.Name, sub.Name, strings.TrimSpace(string(output)), files["main.go"]))
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), runTimeout)
+	defer cancel()
+	var stdout, stderr bytes.Buffer
+	program := exec.CommandContext(ctx, filepath.Join(dir, "snippet"))
+	program.Dir = dir
+	program.Stdout, program.Stderr = &stdout, &stderr
+	err = program.Run()
+
+	result := runResult{section: sec.Name, subsection: sub.Name, stdout: stdout.String(), stderr: stderr.String()}
+	var exitError *exec.ExitError
+	switch {
+	case ctx.Err() != nil:
+		result.timedOut = true
+		result.exitCode = -1
+	case errors.As(err, &exitError):
+		result.exitCode = exitError.ExitCode()
+	case err != nil:
+		return runResult{}, withKind(ErrNotRunnable, fmt.Errorf("cannot start %s/%s: %w", sec.Name, sub.Name, err))
+	}
+	return result, nil
+}
+
+// goToolchain returns the release of the go toolchain at goTool, such as
+// "go1.22.3", or the one gosyn was built with when it cannot tell.
+func goToolchain(goTool string) string {
+	output, err := exec.Command(goTool, "env", "GOVERSION").Output()
+	if toolchain := strings.TrimSpace(string(output)); err == nil && version.IsValid(toolchain) {
+		return toolchain
+	}
+	return runtime.Version()
+}
+
+// snippetGoVersion returns the go directive of the module a snippet of sub
+// is built in: its MinGo, so it builds as the release it was written for,
+// or else the language version of toolchain, "1.22" for "go1.22.3".
+func snippetGoVersion(sub reference.Subsection, toolchain string) string {
+	if sub.MinGo != "" {
+		return strings.TrimPrefix(sub.MinGo, "go")
+	}
+	return strings.TrimPrefix(version.Lang(toolchain), "go")
+}
+
+// formatRunResult shows what a snippet printed on stdout and stderr and,
+// unless it succeeded, how it ended.
+func formatRunResult(result runResult) string {
+	output := fmt.Sprintf("%sOutput%s of %s%s%s/%s%s%s:\n",
+		style(roleHeading), Reset, // Output
+		style(roleSection), result.section, Reset, // sectionName
+		style(roleSubsection), result.subsection, Reset, // subsectionName
+	)
+	if result.stdout == "" && result.stderr == "" {
+		output += fmt.Sprintf("%s(no output)%s\n", style(roleMeta), Reset)
+	}
+	if result.stdout != "" {
+		output += fmt.Sprintf("%sstdout%s:\n%s\n", style(roleHeading), Reset, strings.TrimRight(result.stdout, "\n"))
+	}
+	if result.stderr != "" {
+		output += fmt.Sprintf("%sstderr%s:\n%s\n", style(roleHeading), Reset, strings.TrimRight(result.stderr, "\n"))
+	}
+	switch {
+	case result.timedOut:
+		output += fmt.Sprintf("%sKilled after %v%s\n", style(roleWarning), runTimeout, Reset)
+	case result.exitCode != 0:
+		output += fmt.Sprintf("%sExit status %d%s\n", style(roleWarning), result.exitCode, Reset)
+	}
+	return strings.TrimSuffix(output, "\n")
+}
+
+// useUnused adds "_ = name" after the statement declaring each local
+// variable src never uses. go build rejects unused variables, but snippets
+// often declare one only to show how.
+func useUnused(src string) string {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "main.go", src, 0)
+	if err != nil {
+		return src
+	}
+	type insertion struct {
+		offset int
+		text   string
+	}
+	var insertions []insertion
+	config := types.Config{
+		Importer: importer.Default(),
+		Error: func(err error) {
+			var typeError types.Error
+			if !errors.As(err, &typeError) {
+				return
+			}
+			name, ok := strings.CutPrefix(typeError.Msg, "declared and not used: ")
+			if !ok {
+				return
+			}
+			stmt := enclosingStatement(file, typeError.Pos)
+			if stmt == nil {
+				return
+			}
+			start := fset.Position(stmt.Pos()).Offset
+			lineStart := strings.LastIndexByte(src[:start], '\n') + 1
+			indent := src[lineStart:start]
+			insertions = append(insertions, insertion{fset.Position(stmt.End()).Offset, "\n" + indent + "_ = " + name})
+		},
+	}
+	config.Check("main", fset, []*ast.File{file}, nil)
+
+	sort.Slice(insertions, func(i, j int) bool { return insertions[i].offset > insertions[j].offset })
+	for _, ins := range insertions {
+		src = src[:ins.offset] + ins.text + src[ins.offset:]
+	}
+	return src
+}
+
+// enclosingStatement returns the statement of a block that contains pos,
+// the innermost when blocks are nested.
+func enclosingStatement(file *ast.File, pos token.Pos) ast.Stmt {
+	var found ast.Stmt
+	ast.Inspect(file, func(n ast.Node) bool {
+		block, ok := n.(*ast.BlockStmt)
+		if !ok {
+			return true
+		}
+		for _, stmt := range block.List {
+			if stmt.Pos() <= pos && pos < stmt.End() {
+				found = stmt
+			}
+		}
+		return true
+	})
+	return found
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"os/exec"
+	"strings"
+	"testing"
+
+	"github.com/bbarrington0099/gosyn/reference"
+)
+
+// Use Unused
+func TestUseUnused(t *testing.T) {
+	src := "package main\n\nimport \"strconv\"\n\nfunc main() {\n\ti, _ := strconv.Atoi(\"42\")\n\tf := func() {\n\t\tn := 1\n\t}\n\tused := 2\n\tprintln(used)\n}\n"
+	want := "package main\n\nimport \"strconv\"\n\nfunc main() {\n\ti, _ := strconv.Atoi(\"42\")\n\t_ = i\n\tf := func() {\n\t\tn := 1\n\t\t_ = n\n\t}\n\t_ = f\n\tused := 2\n\tprintln(used)\n}\n"
+	if got := useUnused(src); got != want {
+		t.Errorf("useUnused() =\n%s\nwant\n%s", got, want)
+	}
+	if got := useUnused("not go"); got != "not go" {
+		t.Errorf("useUnused() changed source it cannot parse: %q", got)
+	}
+}
+
+// Snippet Go Version
+func TestSnippetGoVersion(t *testing.T) {
+	tests := []struct {
+		name      string
+		sub       reference.Subsection
+		toolchain string
+		want      string
+	}{
+		{"minGo", reference.Subsection{MinGo: "1.22"}, "go1.24.2", "1.22"},
+		{"minGo with go prefix", reference.Subsection{MinGo: "go1.23"}, "go1.24.2", "1.23"},
+		{"toolchain language", reference.Subsection{}, "go1.24.2", "1.24"},
+	}
+	for _, tt := range tests {
+		if got := snippetGoVersion(tt.sub, tt.toolchain); got != tt.want {
+			t.Errorf("snippetGoVersion(%s) = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+// Run Snippet
+func TestRunSnippet(t *testing.T) {
+	sections := []reference.Section{
+		{Name: "Functions", Short: "func", Subsections: []reference.Subsection{
+			{Name: "Hello", Lang: "go", Kind: reference.KindCode, Content: "{title:Hello}:\n\n\tfmt.Println(\"hello\")\n\tfmt.Fprintln(os.Stderr, \"oops\")\n\tos.Exit(3)"},
+			{Name: "Template", Lang: "go", Kind: reference.KindTemplate, Content: "\tfunc <name>() {}"},
+			{Name: "Untagged", Lang: "go", Content: "\tfmt.Println(1)"},
+			{Name: "Declaration", Lang: "go", Kind: reference.KindCode, Content: "\tfunc add(a, b int) int {\n\t\treturn a + b\n\t}"},
+			{Name: "RangeOverInt", Lang: "go", Kind: reference.KindCode, MinGo: "1.22", Content: "\tfor i := range 2 {\n\t\tfmt.Println(i)\n\t}"},
+			{Name: "Future", Lang: "go", Kind: reference.KindCode, MinGo: "1.999", Content: "\tfmt.Println(1)"},
+		}},
+	}
+
+	for _, name := range []string{"Template", "Untagged"} {
+		_, err := runSnippet(sections, "func", name)
+		if errorCode(err) != codeNotRunnable || !strings.Contains(err.Error(), "Functions/"+name+" is not a runnable program") {
+			t.Errorf("runSnippet(%s) error = %v, want it refused", name, err)
+		}
+	}
+	if _, err := runSnippet(sections, "func", "Declaration"); errorCode(err) != codeNotRunnable || !strings.Contains(err.Error(), "no statements to run") {
+		t.Errorf("runSnippet(Declaration) error = %v, want it refused", err)
+	}
+	if _, err := runSnippet(sections, "func", "Nope"); errorCode(err) != codeNotFound {
+		t.Errorf("runSnippet(Nope) error = %v, want not found", err)
+	}
+
+	if testing.Short() {
+		t.Skip("skipping build of a snippet in short mode")
+	}
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("go toolchain not found")
+	}
+	result, err := runSnippet(sections, "func", "hello")
+	if err != nil {
+		t.Fatalf("runSnippet() error = %v", err)
+	}
+	if result.stdout != "hello\n" || result.stderr != "oops\n" || result.exitCode != 3 || result.timedOut {
+		t.Errorf("runSnippet() = %+v", result)
+	}
+	if result, err := runSnippet(sections, "func", "RangeOverInt"); err != nil || result.stdout != "0\n1\n" {
+		t.Errorf("runSnippet(RangeOverInt) = %+v, %v, want it built with its minGo", result, err)
+	}
+	if _, err := runSnippet(sections, "func", "Future"); errorCode(err) != codeNotRunnable || !strings.Contains(err.Error(), "needs Go 1.999") {
+		t.Errorf("runSnippet(Future) error = %v, want it refused for the toolchain", err)
+	}
+	output := stripANSI(formatRunResult(result))
+	for _, want := range []string{"Output of Functions/Hello:", "stdout:\nhello", "stderr:\noops", "Exit status 3"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("formatRunResult() = %q, want it to contain %q", output, want)
+		}
+	}
+}
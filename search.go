@@ -0,0 +1,57 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/bbarrington0099/gosyn/reference"
+)
+
+// searchSections searches sections with reference.Search, reporting an
+// invalid regular expression as an invalid_query error.
+func searchSections(sections []reference.Section, query string, useRegex bool) ([]reference.SearchResult, error) {
+	results, err := reference.Search(sections, query, useRegex)
+	if err != nil {
+		return nil, withKind(ErrInvalidQuery, err)
+	}
+	return results, nil
+}
+
+func formatSearchResults(query string, results []reference.SearchResult) string {
+	if len(results) == 0 {
+		return fmt.Sprintf("No results for %s\"%s\"%s\n", style(roleArg), query, Reset)
+	}
+	output := fmt.Sprintf("%sSearch results%s for %s\"%s\"%s:\n",
+		style(roleHeading), Reset, // Search results
+		style(roleArg), query, Reset, // query
+	)
+	for _, result := range results {
+		output += fmt.Sprintf(" - %s%s%s %s%s%s\n",
+			style(roleSection), result.Section, Reset, // section
+			style(roleSubsection), result.Subsection, Reset, // subsection
+		)
+		for _, line := range result.Lines {
+			output += fmt.Sprintf("   %s%4d%s  %s\n", style(roleMeta), line.Number, Reset, highlightMatches(strings.TrimSpace(line.Text), line))
+		}
+	}
+	return output
+}
+
+// highlightMatches renders text, which is line.Text with surrounding
+// whitespace trimmed, with every match in line styled as roleMatch.
+func highlightMatches(text string, line reference.SearchLine) string {
+	offset := strings.Index(line.Text, text)
+	var out strings.Builder
+	at := 0
+	for _, m := range line.Matches {
+		start, end := m[0]-offset, m[1]-offset
+		if start < at || end > len(text) {
+			continue
+		}
+		out.WriteString(text[at:start])
+		out.WriteString(style(roleMatch) + text[start:end] + Reset)
+		at = end
+	}
+	out.WriteString(text[at:])
+	return out.String()
+}
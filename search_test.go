@@ -0,0 +1,32 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/bbarrington0099/gosyn/reference"
+)
+
+// Format Search Results
+func TestFormatSearchResults(t *testing.T) {
+	results := []reference.SearchResult{{
+		Section:    "ErrorHandling",
+		Subsection: "PanicRecover",
+		Score:      1,
+		Lines:      []reference.SearchLine{{Number: 3, Text: "\tif r := recover(); r != nil {", Matches: [][]int{{9, 16}}}},
+	}}
+
+	got := formatSearchResults("recover", results)
+	for _, want := range []string{
+		style(roleSection) + "ErrorHandling" + Reset + " " + style(roleSubsection) + "PanicRecover" + Reset,
+		"if r := " + style(roleMatch) + "recover" + Reset + "(); r != nil {",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("formatSearchResults() = %q, want contains %q", got, want)
+		}
+	}
+
+	if got := formatSearchResults("nothing", nil); !strings.Contains(got, "No results") {
+		t.Errorf("formatSearchResults() with no results = %q", got)
+	}
+}
@@ -0,0 +1,39 @@
+package main
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/bbarrington0099/gosyn/reference"
+)
+
+// initializeSections loads the built-in sections and merges in those under
+// userSectionsDir(), see the reference package for their format.
+func initializeSections() []reference.Section {
+	sections, err := reference.Builtin()
+	if err != nil {
+		panic(err)
+	}
+
+	dir := userSectionsDir()
+	if dir == "" {
+		return sections
+	}
+	user, err := reference.Load(os.DirFS(dir), ".", dir)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		warn(warnUserSections, "skipping user sections: %v", err)
+	}
+	return reference.Merge(sections, user)
+}
+
+// userSectionsDir returns the directory user-defined sections are loaded
+// from, the sections directory under configDir().
+func userSectionsDir() string {
+	dir := configDir()
+	if dir == "" {
+		return ""
+	}
+	return filepath.Join(dir, "sections")
+}
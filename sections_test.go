@@ -0,0 +1,75 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/bbarrington0099/gosyn/reference"
+)
+
+// Embedded Sections
+func TestInitializeSections(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	sections := initializeSections()
+	if len(sections) == 0 {
+		t.Fatal("initializeSections() returned no sections")
+	}
+	for _, sec := range sections {
+		if sec.Name == "" || sec.Short == "" {
+			t.Errorf("section %+v is missing a name or short name", sec)
+		}
+		if len(sec.Subsections) == 0 {
+			t.Errorf("section %q has no subsections", sec.Name)
+		}
+		for _, sub := range sec.Subsections {
+			if strings.TrimSpace(sub.Content) == "" {
+				t.Errorf("subsection %q in section %q has no content", sub.Name, sec.Name)
+			}
+		}
+	}
+}
+
+// User Sections
+func TestInitializeSectionsUserDir(t *testing.T) {
+	config := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", config)
+	dir := filepath.Join(config, "gosyn", "sections")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	files := map[string]string{
+		"errors.md":  "# err\n\n## Wrapping\n\nfmt.Errorf(\"ctx: %w\", err)\n",
+		"logging.md": "# Logging\nshort: log\n\n## Setup\n\nslog.New(h)\n",
+	}
+	for name, data := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(data), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	sections := initializeSections()
+
+	i := reference.Find(sections, "ErrorHandling")
+	if i < 0 {
+		t.Fatal("built-in section ErrorHandling missing")
+	}
+	last := sections[i].Subsections[len(sections[i].Subsections)-1]
+	if last.Name != "Wrapping" || last.Origin != filepath.Join(dir, "errors.md") {
+		t.Errorf("ErrorHandling was not extended by errors.md, last subsection = %+v", last)
+	}
+
+	logging := sections[len(sections)-1]
+	if logging.Name != "Logging" || logging.Origin != filepath.Join(dir, "logging.md") {
+		t.Errorf("user section Logging was not appended, got %+v", logging)
+	}
+
+	listing := listSections(sections)
+	for _, want := range []string{"Wrapping" + Reset + "*", "(*extended by: " + filepath.Join(dir, "errors.md"), "(user: " + filepath.Join(dir, "logging.md")} {
+		if !strings.Contains(listing, want) {
+			t.Errorf("listSections() does not contain %q", want)
+		}
+	}
+}
@@ -0,0 +1,197 @@
+package main
+
+import (
+	"go/scanner"
+	"go/token"
+	"sort"
+	"strings"
+
+	"github.com/bbarrington0099/gosyn/reference"
+)
+
+// stdImports maps the package names snippets use without importing them to
+// their import paths, so snippetProgram can add the imports.
+var stdImports = map[string]string{
+	"atomic":   "sync/atomic",
+	"bufio":    "bufio",
+	"bytes":    "bytes",
+	"context":  "context",
+	"errors":   "errors",
+	"filepath": "path/filepath",
+	"fmt":      "fmt",
+	"http":     "net/http",
+	"io":       "io",
+	"iter":     "iter",
+	"json":     "encoding/json",
+	"log":      "log",
+	"math":     "math",
+	"os":       "os",
+	"rand":     "math/rand",
+	"reflect":  "reflect",
+	"regexp":   "regexp",
+	"sort":     "sort",
+	"strconv":  "strconv",
+	"strings":  "strings",
+	"sync":     "sync",
+	"testing":  "testing",
+	"time":     "time",
+	"unicode":  "unicode",
+	"utf8":     "unicode/utf8",
+}
+
+// snippetCode returns the Go code in a subsection: its tab-indented lines
+// without markup and with one tab of indentation removed. Unindented lines,
+// such as the "{title:...}:" line most snippets open with, are dropped.
+func snippetCode(sub reference.Subsection) string {
+	var lines []string
+	for _, line := range strings.Split(reference.RenderMarkup(sub.Content, reference.Plain), "\n") {
+		switch {
+		case strings.HasPrefix(line, "\t"):
+			lines = append(lines, line[1:])
+		case strings.TrimSpace(line) == "":
+			lines = append(lines, "")
+		}
+	}
+	return strings.Trim(strings.Join(lines, "\n"), "\n")
+}
+
+// snippetProgram turns snippet code into a package main source file. Code
+// with its own package clause is returned as it is. Otherwise top-level
+// declarations stay at the top level, any other statements go in a
+// generated func main, empty when there are none, and imports are added for
+// the standard packages in stdImports the code refers to.
+func snippetProgram(code string) string {
+	if strings.HasPrefix(code, "package ") || strings.Contains(code, "\npackage ") {
+		return code + "\n"
+	}
+
+	var decls, stmts []string
+	for _, stmt := range topLevelStatements(code) {
+		if isDeclaration(stmt) {
+			decls = append(decls, stmt)
+		} else {
+			stmts = append(stmts, stmt)
+		}
+	}
+
+	var out strings.Builder
+	out.WriteString("package main\n")
+	if imports := snippetImports(code); len(imports) > 0 {
+		out.WriteString("\nimport (\n")
+		for _, path := range imports {
+			out.WriteString("\t\"" + path + "\"\n")
+		}
+		out.WriteString(")\n")
+	}
+	for _, decl := range decls {
+		out.WriteString("\n" + decl + "\n")
+	}
+	switch {
+	case len(stmts) == 0 && !strings.Contains(code, "func main()"):
+		out.WriteString("\nfunc main() {}\n")
+	case len(stmts) > 0:
+		out.WriteString("\nfunc main() {\n")
+		for _, stmt := range stmts {
+			for _, line := range strings.Split(stmt, "\n") {
+				if line != "" {
+					line = "\t" + line
+				}
+				out.WriteString(line + "\n")
+			}
+		}
+		out.WriteString("}\n")
+	}
+	return out.String()
+}
+
+// snippetRuns reports whether the program snippetProgram makes of code
+// does anything when run: the code has its own func main, or statements to
+// put in the generated one.
+func snippetRuns(code string) bool {
+	if strings.Contains(code, "func main()") {
+		return true
+	}
+	if strings.HasPrefix(code, "package ") || strings.Contains(code, "\npackage ") {
+		return false
+	}
+	for _, stmt := range topLevelStatements(code) {
+		if !isDeclaration(stmt) {
+			return true
+		}
+	}
+	return false
+}
+
+// topLevelStatements splits code at its unindented lines, other than
+// those closing a block, so that each part is one declaration or
+// statement with its body. Blank lines end a part.
+func topLevelStatements(code string) []string {
+	var parts []string
+	var current []string
+	flush := func() {
+		if len(current) > 0 {
+			parts = append(parts, strings.Join(current, "\n"))
+		}
+		current = nil
+	}
+	for _, line := range strings.Split(code, "\n") {
+		switch {
+		case line == "":
+			flush()
+		case strings.HasPrefix(line, "\t"), strings.HasPrefix(line, " "),
+			strings.HasPrefix(line, "}"), strings.HasPrefix(line, ")"):
+			current = append(current, line)
+		default:
+			flush()
+			current = append(current, line)
+		}
+	}
+	flush()
+	return parts
+}
+
+// isDeclaration reports whether stmt belongs at the top level of a file:
+// a function or method, a type, an import or a package-level var or const.
+// A function literal, written "func() { ... }()", is a statement.
+func isDeclaration(stmt string) bool {
+	for _, prefix := range []string{"func ", "type ", "import ", "var ", "const "} {
+		if strings.HasPrefix(stmt, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// snippetImports returns the import paths of the stdImports packages code
+// selects from, as in "fmt.Println", in sorted order.
+func snippetImports(code string) []string {
+	var s scanner.Scanner
+	fset := token.NewFileSet()
+	s.Init(fset.AddFile("", fset.Base(), len(code)), []byte(code), nil, 0)
+
+	used := map[string]bool{}
+	var previous, name string
+	for {
+		_, tok, lit := s.Scan()
+		if tok == token.EOF {
+			break
+		}
+		if tok == token.PERIOD && name != "" {
+			if path, ok := stdImports[name]; ok {
+				used[path] = true
+			}
+		}
+		name = ""
+		if tok == token.IDENT && previous != "." {
+			name = lit
+		}
+		previous = tok.String()
+	}
+
+	var paths []string
+	for path := range used {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	return paths
+}
@@ -0,0 +1,163 @@
+package main
+
+import (
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"reflect"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/bbarrington0099/gosyn/reference"
+)
+
+// checkProgram parses and type-checks a snippetProgram source file for the
+// language version goVersion, as runSnippet's module would build it, or the
+// latest when it is empty. Unused variables are allowed, since a snippet
+// often declares something only to show how.
+func checkProgram(src string, goVersion string, imp types.Importer) []error {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "snippet.go", src, parser.AllErrors)
+	if err != nil {
+		return []error{err}
+	}
+	var errs []error
+	config := types.Config{
+		Importer:  imp,
+		GoVersion: goVersion,
+		Error: func(err error) {
+			if !strings.Contains(err.Error(), "declared and not used") {
+				errs = append(errs, err)
+			}
+		},
+	}
+	config.Check("main", fset, []*ast.File{file}, nil)
+	return errs
+}
+
+// Built-in Snippets Compile
+func TestSnippetsCompile(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	imp := importer.Default()
+
+	checked := 0
+	for _, sec := range initializeSections() {
+		for _, sub := range sec.Subsections {
+			if sub.Lang != "go" {
+				continue
+			}
+			if sub.Kind == "" {
+				t.Errorf("%s/%s has no \"kind: code\" or \"kind: template\" metadata", sec.Name, sub.Name)
+				continue
+			}
+			if sub.Kind != reference.KindCode {
+				continue
+			}
+			src := snippetProgram(snippetCode(sub))
+			if errs := checkProgram(src, "go"+snippetGoVersion(sub, runtime.Version()), imp); len(errs) > 0 {
+				t.Errorf("%s/%s does not compile:\n%v\nprogram:\n%s", sec.Name, sub.Name, errs, src)
+			}
+			checked++
+		}
+	}
+	if checked == 0 {
+		t.Error("no snippet is tagged \"kind: code\"")
+	}
+}
+
+// A rotten snippet fails the check
+func TestCheckProgramRejects(t *testing.T) {
+	imp := importer.Default()
+	tests := []struct {
+		name      string
+		code      string
+		goVersion string
+	}{
+		{"syntax error", "x := ", ""},
+		{"type error", "var n int = \"one\"", ""},
+		{"undefined name", "fmt.Println(missing)", ""},
+		{"placeholder", "var <name> <type>", ""},
+		{"newer than the go version", "for range 3 {}", "go1.21"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if errs := checkProgram(snippetProgram(tt.code), tt.goVersion, imp); len(errs) == 0 {
+				t.Errorf("checkProgram() accepted %q", tt.code)
+			}
+		})
+	}
+}
+
+// Snippet Code
+func TestSnippetCode(t *testing.T) {
+	sub := reference.Subsection{Content: "{title:Closures}:\n\n\tfunc f() {\n\t\t{kw:return}\n\t}\n\n\tf()\nNot code\n"}
+	want := "func f() {\n\treturn\n}\n\nf()"
+	if got := snippetCode(sub); got != want {
+		t.Errorf("snippetCode() = %q, want %q", got, want)
+	}
+}
+
+// Snippet Runs
+func TestSnippetRuns(t *testing.T) {
+	tests := []struct {
+		name string
+		code string
+		want bool
+	}{
+		{"statements", "x := 1\nfmt.Println(x)", true},
+		{"declarations only", "func add(a, b int) int {\n\treturn a + b\n}\n\ntype point struct{ x, y int }", false},
+		{"own main", "func main() {\n\tfmt.Println(1)\n}", true},
+		{"test file", "package math_test\n\nfunc TestAdd(t *testing.T) {}", false},
+	}
+	for _, tt := range tests {
+		if got := snippetRuns(tt.code); got != tt.want {
+			t.Errorf("snippetRuns(%s) = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+// Snippet Program
+func TestSnippetProgram(t *testing.T) {
+	tests := []struct {
+		name string
+		code string
+		want string
+	}{
+		{
+			name: "declarations only",
+			code: "type T struct{}\n\nfunc (T) String() string {\n\treturn fmt.Sprint(1)\n}",
+			want: "package main\n\nimport (\n\t\"fmt\"\n)\n\ntype T struct{}\n\nfunc (T) String() string {\n\treturn fmt.Sprint(1)\n}\n\nfunc main() {}\n",
+		},
+		{
+			name: "declarations and statements",
+			code: "func f() {}\n\ndefer func() {\n\trecover()\n}()\nf()",
+			want: "package main\n\nfunc f() {}\n\nfunc main() {\n\tdefer func() {\n\t\trecover()\n\t}()\n\tf()\n}\n",
+		},
+		{
+			name: "own package clause",
+			code: "package demo\n\nvar X int",
+			want: "package demo\n\nvar X int\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := snippetProgram(tt.code); got != tt.want {
+				t.Errorf("snippetProgram() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// Snippet Imports
+func TestSnippetImports(t *testing.T) {
+	code := "s := strings.ToUpper(\"fmt.Println\")\nhttp.HandleFunc(\"/\", nil)\nx.time.Now()\n// os.Exit(1)"
+	want := []string{"net/http", "strings"}
+	if got := snippetImports(code); !reflect.DeepEqual(got, want) {
+		t.Errorf("snippetImports() = %q, want %q", got, want)
+	}
+}
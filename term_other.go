@@ -0,0 +1,28 @@
+//go:build !(linux || darwin || freebsd || netbsd || openbsd)
+
+package main
+
+import (
+	"errors"
+	"os"
+)
+
+type terminalState struct{}
+
+// makeRaw is not supported on this platform; the REPL falls back to
+// reading whole lines after a prompt, without editing.
+func makeRaw(fd uintptr) (*terminalState, error) {
+	return nil, errors.New("raw terminal mode is not supported on this platform")
+}
+
+func restoreTerminal(fd uintptr, state *terminalState) error {
+	return nil
+}
+
+func terminalSize(fd uintptr) (int, int, error) {
+	return 0, 0, errors.New("terminal size is not available on this platform")
+}
+
+// notifyResize does nothing on this platform; the browser picks up a new
+// size on the next key press instead.
+func notifyResize(ch chan<- os.Signal) {}
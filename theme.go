@@ -0,0 +1,398 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/bbarrington0099/gosyn/reference"
+)
+
+// The markup roles of the reference package, styled by themes like the
+// roles below.
+const (
+	roleTitle       = reference.RoleTitle
+	roleHeading     = reference.RoleHeading
+	roleKeyword     = reference.RoleKeyword
+	rolePlaceholder = reference.RolePlaceholder
+	roleLiteral     = reference.RoleLiteral
+	roleOperator    = reference.RoleOperator
+	roleFlow        = reference.RoleFlow
+	roleLabel       = reference.RoleLabel
+	roleNote        = reference.RoleNote
+	roleIdent       = reference.RoleIdent
+	roleBuiltin     = reference.RoleBuiltin
+)
+
+// Roles for the text gosyn prints around snippets. Together with the
+// markup roles above they are everything a theme styles; headings in the
+// output use roleHeading like headings inside a snippet.
+const (
+	roleSection    = "section"    // section name
+	roleSubsection = "subsection" // subsection name
+	roleCommand    = "command"    // gosyn command in help text
+	roleArg        = "arg"        // argument or flag in help text, search query
+	roleMeta       = "meta"       // short names, origins, line numbers
+	roleMatch      = "match"      // search match
+	roleError      = "error"      // ERROR prefix
+	roleWarning    = "warning"    // WARNING prefix
+)
+
+// terminalRenderer is the reference.Renderer that styles content in the
+// active theme.
+func terminalRenderer(role string, text string) string {
+	sequence := style(role)
+	if sequence == "" {
+		return text
+	}
+	return sequence + text + Reset
+}
+
+// Colour depths a theme can be rendered at, chosen with a "-256" or
+// "-truecolor" suffix on the theme name.
+const (
+	depth16        = 16
+	depth256       = 256
+	depthTruecolor = 1 << 24
+)
+
+// themeStyle describes how one role looks. attrs holds SGR attributes such
+// as "1" (bold), "3" (italic), "4" (underline) or "7" (reverse); basic is
+// the foreground used on 16-colour terminals and hex the colour used on
+// 256-colour and truecolor ones. Either colour may be empty.
+type themeStyle struct {
+	attrs string
+	basic string
+	hex   string
+}
+
+type theme map[string]themeStyle
+
+var themes = map[string]theme{
+	"dark": {
+		roleTitle:       {attrs: "1;3"},
+		roleHeading:     {attrs: "1;4"},
+		roleKeyword:     {basic: "36", hex: "#5fd7d7"},
+		rolePlaceholder: {basic: "33", hex: "#d7d75f"},
+		roleLiteral:     {basic: "32", hex: "#87d75f"},
+		roleOperator:    {attrs: "1", basic: "35", hex: "#d787d7"},
+		roleFlow:        {attrs: "1", basic: "33", hex: "#ffd75f"},
+		roleLabel:       {attrs: "1", basic: "36", hex: "#5fd7ff"},
+		roleNote:        {attrs: "3", hex: "#8a8a8a"},
+		roleIdent:       {basic: "37", hex: "#d7d7af"},
+		roleBuiltin:     {basic: "34", hex: "#5f87ff"},
+		roleSection:     {attrs: "1", basic: "32", hex: "#87d75f"},
+		roleSubsection:  {basic: "33", hex: "#d7d75f"},
+		roleCommand:     {attrs: "1", basic: "36", hex: "#5fd7ff"},
+		roleArg:         {attrs: "3"},
+		roleMeta:        {attrs: "3", hex: "#8a8a8a"},
+		roleMatch:       {attrs: "1", basic: "31", hex: "#ff5f5f"},
+		roleError:       {attrs: "1", basic: "31", hex: "#ff5f5f"},
+		roleWarning:     {attrs: "1", basic: "35", hex: "#d787d7"},
+	},
+	"light": {
+		roleTitle:       {attrs: "1;3"},
+		roleHeading:     {attrs: "1;4"},
+		roleKeyword:     {basic: "34", hex: "#005fd7"},
+		rolePlaceholder: {attrs: "1", basic: "35", hex: "#af00af"},
+		roleLiteral:     {basic: "32", hex: "#008700"},
+		roleOperator:    {attrs: "1", basic: "31", hex: "#af0000"},
+		roleFlow:        {attrs: "1", basic: "34", hex: "#005faf"},
+		roleLabel:       {attrs: "1", basic: "35", hex: "#870087"},
+		roleNote:        {attrs: "3", basic: "90", hex: "#6c6c6c"},
+		roleIdent:       {basic: "36", hex: "#005f87"},
+		roleBuiltin:     {basic: "35", hex: "#870087"},
+		roleSection:     {attrs: "1", basic: "32", hex: "#008700"},
+		roleSubsection:  {attrs: "1", basic: "35", hex: "#af00af"},
+		roleCommand:     {attrs: "1", basic: "34", hex: "#005fd7"},
+		roleArg:         {attrs: "3"},
+		roleMeta:        {attrs: "3", basic: "90", hex: "#6c6c6c"},
+		roleMatch:       {attrs: "1", basic: "31", hex: "#d70000"},
+		roleError:       {attrs: "1", basic: "31", hex: "#d70000"},
+		roleWarning:     {attrs: "1", basic: "35", hex: "#af00af"},
+	},
+	"high-contrast": {
+		roleTitle:       {attrs: "1;4", basic: "97", hex: "#ffffff"},
+		roleHeading:     {attrs: "1;4", basic: "97", hex: "#ffffff"},
+		roleKeyword:     {attrs: "1", basic: "96", hex: "#00ffff"},
+		rolePlaceholder: {attrs: "1", basic: "93", hex: "#ffff00"},
+		roleLiteral:     {attrs: "1", basic: "92", hex: "#00ff00"},
+		roleOperator:    {attrs: "1", basic: "95", hex: "#ff00ff"},
+		roleFlow:        {attrs: "1", basic: "93", hex: "#ffff00"},
+		roleLabel:       {attrs: "1", basic: "96", hex: "#00ffff"},
+		roleNote:        {attrs: "3", basic: "97", hex: "#ffffff"},
+		roleIdent:       {basic: "97", hex: "#ffffff"},
+		roleBuiltin:     {attrs: "1", basic: "94", hex: "#5f87ff"},
+		roleSection:     {attrs: "1", basic: "92", hex: "#00ff00"},
+		roleSubsection:  {attrs: "1", basic: "93", hex: "#ffff00"},
+		roleCommand:     {attrs: "1", basic: "96", hex: "#00ffff"},
+		roleArg:         {attrs: "1;3", basic: "97", hex: "#ffffff"},
+		roleMeta:        {attrs: "3", basic: "97", hex: "#ffffff"},
+		roleMatch:       {attrs: "1;7", basic: "91", hex: "#ff0000"},
+		roleError:       {attrs: "1", basic: "91", hex: "#ff0000"},
+		roleWarning:     {attrs: "1", basic: "95", hex: "#ff00ff"},
+	},
+	"solarized": {
+		roleTitle:       {attrs: "1;3", basic: "34", hex: "#268bd2"},
+		roleHeading:     {attrs: "1;4", basic: "34", hex: "#268bd2"},
+		roleKeyword:     {basic: "32", hex: "#859900"},
+		rolePlaceholder: {basic: "35", hex: "#d33682"},
+		roleLiteral:     {basic: "36", hex: "#2aa198"},
+		roleOperator:    {attrs: "1", basic: "91", hex: "#cb4b16"},
+		roleFlow:        {attrs: "1", basic: "32", hex: "#859900"},
+		roleLabel:       {attrs: "1", basic: "95", hex: "#6c71c4"},
+		roleNote:        {attrs: "3", basic: "90", hex: "#586e75"},
+		roleIdent:       {basic: "34", hex: "#268bd2"},
+		roleBuiltin:     {basic: "33", hex: "#b58900"},
+		roleSection:     {attrs: "1", basic: "32", hex: "#859900"},
+		roleSubsection:  {basic: "33", hex: "#b58900"},
+		roleCommand:     {attrs: "1", basic: "36", hex: "#2aa198"},
+		roleArg:         {attrs: "3"},
+		roleMeta:        {attrs: "3", basic: "90", hex: "#586e75"},
+		roleMatch:       {attrs: "1", basic: "31", hex: "#dc322f"},
+		roleError:       {attrs: "1", basic: "31", hex: "#dc322f"},
+		roleWarning:     {attrs: "1", basic: "35", hex: "#d33682"},
+	},
+	"monochrome": {
+		roleTitle:       {attrs: "1;3"},
+		roleHeading:     {attrs: "1;4"},
+		roleKeyword:     {attrs: "1"},
+		rolePlaceholder: {attrs: "4"},
+		roleOperator:    {attrs: "1"},
+		roleFlow:        {attrs: "1"},
+		roleLabel:       {attrs: "1"},
+		roleNote:        {attrs: "3"},
+		roleSection:     {attrs: "1"},
+		roleSubsection:  {attrs: "4"},
+		roleCommand:     {attrs: "1"},
+		roleArg:         {attrs: "3"},
+		roleMeta:        {attrs: "2"},
+		roleMatch:       {attrs: "7"},
+		roleError:       {attrs: "1"},
+		roleWarning:     {attrs: "1"},
+	},
+}
+
+const defaultTheme = "dark"
+
+var (
+	colorOn     = true
+	activeTheme = themes[defaultTheme]
+	activeDepth = depth16
+	roleStyles  = activeTheme.sequences(activeDepth)
+)
+
+// style returns the escape sequence for role in the active theme, or ""
+// when the role is unstyled or colour is off.
+func style(role string) string {
+	return roleStyles[role]
+}
+
+// setTheme makes name, such as "light" or "solarized-truecolor", the
+// active theme.
+func setTheme(name string) error {
+	t, depth, err := parseThemeName(name)
+	if err != nil {
+		return err
+	}
+	activeTheme, activeDepth = t, depth
+	applyTheme()
+	return nil
+}
+
+func applyTheme() {
+	if !colorOn {
+		roleStyles = map[string]string{}
+		return
+	}
+	roleStyles = activeTheme.sequences(activeDepth)
+}
+
+// sequences returns the escape sequence of every role t styles.
+func (t theme) sequences(depth int) map[string]string {
+	sequences := make(map[string]string, len(t))
+	for role, s := range t {
+		sequences[role] = s.sequence(depth)
+	}
+	return sequences
+}
+
+// themeNames lists every name --theme accepts, including depth variants.
+func themeNames() []string {
+	var names []string
+	for name := range themes {
+		names = append(names, name, name+"-256", name+"-truecolor")
+	}
+	sort.Strings(names)
+	return names
+}
+
+// parseThemeName splits a theme name such as "solarized-256" into the
+// theme and the colour depth it is rendered at.
+func parseThemeName(name string) (theme, int, error) {
+	depth := depth16
+	base := name
+	switch {
+	case strings.HasSuffix(name, "-256"):
+		base, depth = strings.TrimSuffix(name, "-256"), depth256
+	case strings.HasSuffix(name, "-truecolor"):
+		base, depth = strings.TrimSuffix(name, "-truecolor"), depthTruecolor
+	case strings.HasSuffix(name, "-24bit"):
+		base, depth = strings.TrimSuffix(name, "-24bit"), depthTruecolor
+	}
+	t, ok := themes[base]
+	if !ok {
+		return nil, 0, withKind(ErrUsage, fmt.Errorf("unknown theme \"%s\", want one of: %s", name, strings.Join(themeNames(), ", ")))
+	}
+	return t, depth, nil
+}
+
+// sequence returns the ANSI escape sequence for s at the given colour
+// depth, or "" when s has no attributes or colour.
+func (s themeStyle) sequence(depth int) string {
+	var params []string
+	if s.attrs != "" {
+		params = append(params, s.attrs)
+	}
+	r, g, b, hasHex := parseHex(s.hex)
+	switch {
+	case depth == depthTruecolor && hasHex:
+		params = append(params, fmt.Sprintf("38;2;%d;%d;%d", r, g, b))
+	case depth == depth256 && hasHex:
+		params = append(params, fmt.Sprintf("38;5;%d", rgbTo256(r, g, b)))
+	case s.basic != "":
+		params = append(params, s.basic)
+	}
+	if len(params) == 0 {
+		return ""
+	}
+	return "\033[" + strings.Join(params, ";") + "m"
+}
+
+func parseHex(hex string) (r, g, b int, ok bool) {
+	if len(hex) != 7 || hex[0] != '#' {
+		return 0, 0, 0, false
+	}
+	v, err := strconv.ParseUint(hex[1:], 16, 32)
+	if err != nil {
+		return 0, 0, 0, false
+	}
+	return int(v >> 16 & 0xff), int(v >> 8 & 0xff), int(v & 0xff), true
+}
+
+// rgbTo256 returns the xterm 256-colour palette index closest to r, g, b,
+// picking between the 6x6x6 colour cube and the 24-step grey ramp.
+func rgbTo256(r, g, b int) int {
+	levels := []int{0, 95, 135, 175, 215, 255}
+	nearest := func(v int) int {
+		best := 0
+		for i, level := range levels {
+			if abs(level-v) < abs(levels[best]-v) {
+				best = i
+			}
+		}
+		return best
+	}
+	ri, gi, bi := nearest(r), nearest(g), nearest(b)
+	cube := 16 + 36*ri + 6*gi + bi
+	cubeDist := sq(levels[ri]-r) + sq(levels[gi]-g) + sq(levels[bi]-b)
+
+	grey := min(23, max(0, ((r+g+b)/3-8+5)/10))
+	level := 8 + 10*grey
+	greyDist := sq(level-r) + sq(level-g) + sq(level-b)
+	if greyDist < cubeDist {
+		return 232 + grey
+	}
+	return cube
+}
+
+func abs(v int) int {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
+
+func sq(v int) int {
+	return v * v
+}
+
+// configKeys are the keys the config file may set.
+var configKeys = []string{"theme"}
+
+// configuredTheme returns the theme named by the --theme flag, else by the
+// "theme" key of the config file, else the default theme. Problems with
+// the config file are warned about rather than stopping gosyn.
+func configuredTheme(flagTheme string) string {
+	if flagTheme != "" {
+		return flagTheme
+	}
+	config, err := readConfig()
+	if err != nil {
+		warn(warnConfig, "ignoring config file: %v", err)
+	}
+	for key := range config {
+		if !slices.Contains(configKeys, key) {
+			warn(warnConfig, "unknown config key \"%s\"", key)
+		}
+	}
+	if config["theme"] != "" {
+		return config["theme"]
+	}
+	return defaultTheme
+}
+
+// configDir returns gosyn's configuration directory, $XDG_CONFIG_HOME/gosyn,
+// falling back to the platform's user config directory when
+// XDG_CONFIG_HOME is unset.
+func configDir() string {
+	base := os.Getenv("XDG_CONFIG_HOME")
+	if base == "" {
+		var err error
+		if base, err = os.UserConfigDir(); err != nil {
+			return ""
+		}
+	}
+	return filepath.Join(base, "gosyn")
+}
+
+// readConfig reads "key: value" lines from the config file in configDir(),
+// skipping blank lines and lines starting with "#". A missing file is not
+// an error.
+func readConfig() (map[string]string, error) {
+	config := map[string]string{}
+	dir := configDir()
+	if dir == "" {
+		return config, nil
+	}
+	path := filepath.Join(dir, "config")
+	file, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return config, nil
+	}
+	if err != nil {
+		return config, err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			return config, fmt.Errorf("%s:%d: expected \"key: value\", got %q", path, lineNo, line)
+		}
+		config[strings.TrimSpace(key)] = strings.TrimSpace(value)
+	}
+	return config, scanner.Err()
+}
@@ -0,0 +1,196 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/bbarrington0099/gosyn/reference"
+)
+
+// Parse Theme Name
+func TestParseThemeName(t *testing.T) {
+	tests := []struct {
+		name      string
+		wantDepth int
+		wantErr   bool
+	}{
+		{"dark", depth16, false},
+		{"solarized-256", depth256, false},
+		{"light-truecolor", depthTruecolor, false},
+		{"high-contrast-24bit", depthTruecolor, false},
+		{"neon", 0, true},
+		{"neon-256", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, depth, err := parseThemeName(tt.name)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseThemeName(%q) error = %v, wantErr %v", tt.name, err, tt.wantErr)
+			}
+			if err == nil && depth != tt.wantDepth {
+				t.Errorf("parseThemeName(%q) depth = %d, want %d", tt.name, depth, tt.wantDepth)
+			}
+		})
+	}
+}
+
+// Theme Style Sequence
+func TestThemeStyleSequence(t *testing.T) {
+	tests := []struct {
+		name  string
+		style themeStyle
+		depth int
+		want  string
+	}{
+		{"basic colour", themeStyle{attrs: "1", basic: "36", hex: "#5fd7d7"}, depth16, "\033[1;36m"},
+		{"256 colours", themeStyle{attrs: "1", basic: "36", hex: "#5fd7d7"}, depth256, "\033[1;38;5;80m"},
+		{"truecolor", themeStyle{basic: "36", hex: "#5fd7d7"}, depthTruecolor, "\033[38;2;95;215;215m"},
+		{"no hex falls back to basic", themeStyle{basic: "33"}, depthTruecolor, "\033[33m"},
+		{"attributes only", themeStyle{attrs: "3"}, depth256, "\033[3m"},
+		{"unstyled", themeStyle{}, depth16, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.style.sequence(tt.depth); got != tt.want {
+				t.Errorf("sequence(%d) = %q, want %q", tt.depth, got, tt.want)
+			}
+		})
+	}
+}
+
+// RGB To 256
+func TestRgbTo256(t *testing.T) {
+	tests := []struct {
+		hex  string
+		want int
+	}{
+		{"#000000", 16},
+		{"#ffffff", 231},
+		{"#5fd7d7", 80},
+		{"#808080", 244},
+		{"#dc322f", 166},
+	}
+
+	for _, tt := range tests {
+		r, g, b, ok := parseHex(tt.hex)
+		if !ok {
+			t.Fatalf("parseHex(%q) failed", tt.hex)
+		}
+		if got := rgbTo256(r, g, b); got != tt.want {
+			t.Errorf("rgbTo256(%s) = %d, want %d", tt.hex, got, tt.want)
+		}
+	}
+}
+
+// The default theme keeps the look gosyn had before themes existed.
+func TestDarkThemeMatchesPalette(t *testing.T) {
+	want := map[string]string{
+		roleTitle:       "\033[1;3m",
+		roleHeading:     "\033[1;4m",
+		roleKeyword:     "\033[36m",
+		rolePlaceholder: "\033[33m",
+		roleLiteral:     "\033[32m",
+		roleOperator:    "\033[1;35m",
+		roleFlow:        "\033[1;33m",
+		roleLabel:       "\033[1;36m",
+		roleNote:        "\033[3m",
+		roleIdent:       "\033[37m",
+		roleBuiltin:     "\033[34m",
+		roleError:       "\033[1;31m",
+	}
+	sequences := themes[defaultTheme].sequences(depth16)
+	for role, sequence := range want {
+		if sequences[role] != sequence {
+			t.Errorf("dark theme %s = %q, want %q", role, sequences[role], sequence)
+		}
+	}
+}
+
+// Every theme styles the roles errors and search results rely on.
+func TestThemesComplete(t *testing.T) {
+	for name, th := range themes {
+		for _, role := range []string{roleHeading, roleError, roleMatch, roleSection, roleSubsection} {
+			if th[role].sequence(depth16) == "" {
+				t.Errorf("theme %s leaves %s unstyled", name, role)
+			}
+		}
+	}
+}
+
+// Every theme with colours gives identifiers one, at every depth.
+func TestThemesColourIdentifiers(t *testing.T) {
+	for name, th := range themes {
+		if name == "monochrome" {
+			continue
+		}
+		for depth, colour := range map[int]string{depth16: th[roleIdent].basic, depth256: "38;5;", depthTruecolor: "38;2;"} {
+			if sequence := th[roleIdent].sequence(depth); colour == "" || !strings.Contains(sequence, colour) {
+				t.Errorf("theme %s at depth %d styles %s as %q, want a colour", name, depth, roleIdent, sequence)
+			}
+		}
+	}
+}
+
+// Terminal Renderer
+func TestTerminalRenderer(t *testing.T) {
+	defer setColor(colorOn)
+	setColor(true)
+
+	in := `{title:Loops}: {kw:for} {ph:<i>} < {lit:"x"}`
+	want := style(roleTitle) + "Loops" + Reset + ": " + style(roleKeyword) + "for" + Reset + " " + style(rolePlaceholder) + "<i>" + Reset + " < " + style(roleLiteral) + `"x"` + Reset
+	if got := reference.RenderMarkup(in, terminalRenderer); got != want {
+		t.Errorf("reference.RenderMarkup() = %q, want %q", got, want)
+	}
+}
+
+// Set Theme
+func TestSetTheme(t *testing.T) {
+	defer setTheme(defaultTheme)
+	defer setColor(true)
+
+	if err := setTheme("light"); err != nil {
+		t.Fatalf("setTheme() error = %v", err)
+	}
+	if got := reference.RenderMarkup("{kw:for}", terminalRenderer); got != "\033[34mfor"+Reset {
+		t.Errorf("reference.RenderMarkup() with light theme = %q", got)
+	}
+	setColor(false)
+	if style(roleKeyword) != "" {
+		t.Error("style() with colour off should be empty")
+	}
+	setColor(true)
+	if style(roleKeyword) != "\033[34m" {
+		t.Errorf("setColor(true) did not restore the light theme, got %q", style(roleKeyword))
+	}
+	if err := setTheme("neon"); err == nil || !strings.Contains(err.Error(), "unknown theme") {
+		t.Errorf("setTheme(\"neon\") error = %v, want unknown theme", err)
+	}
+}
+
+// Configured Theme
+func TestConfiguredTheme(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", dir)
+
+	if got := configuredTheme(""); got != defaultTheme {
+		t.Errorf("configuredTheme() without config = %q, want %q", got, defaultTheme)
+	}
+
+	if err := os.MkdirAll(filepath.Join(dir, "gosyn"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	config := "# colours\ntheme: solarized-256\n"
+	if err := os.WriteFile(filepath.Join(dir, "gosyn", "config"), []byte(config), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if got := configuredTheme(""); got != "solarized-256" {
+		t.Errorf("configuredTheme() from config = %q, want %q", got, "solarized-256")
+	}
+	if got := configuredTheme("monochrome"); got != "monochrome" {
+		t.Errorf("configuredTheme() with flag = %q, want %q", got, "monochrome")
+	}
+}